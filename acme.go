@@ -0,0 +1,79 @@
+package porkbun
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const acmeChallengeLabel = "_acme-challenge"
+
+const acmeNotesPrefix = "acme-challenge created="
+
+// ACMEChallengeNotes returns the notes to set on an ACME challenge TXT record at creation.
+// The timestamp is used by CleanupStaleACME to find the stale records.
+func ACMEChallengeNotes(createdAt time.Time) string {
+	return acmeNotesPrefix + createdAt.UTC().Format(time.RFC3339)
+}
+
+// CleanupStaleACME deletes the `_acme-challenge` TXT records created more than olderThan ago.
+// Only the records with notes written by ACMEChallengeNotes are considered,
+// the other records are never deleted.
+// It returns the deleted records.
+func (c *Client) CleanupStaleACME(ctx context.Context, domain string, olderThan time.Duration) ([]Record, error) {
+	if c.readOnly {
+		return nil, ErrReadOnly
+	}
+
+	records, err := c.RetrieveRecords(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := c.getClock().Now().Add(-olderThan)
+
+	var deleted []Record
+
+	for _, record := range records {
+		if !isACMEChallenge(record) {
+			continue
+		}
+
+		createdAt, ok := parseACMEChallengeNotes(record.Notes)
+		if !ok || !createdAt.Before(limit) {
+			continue
+		}
+
+		err = c.DeleteRecord(ctx, domain, record.ID)
+		if err != nil {
+			return deleted, fmt.Errorf("failed to delete record %s: %w", record.ID, err)
+		}
+
+		deleted = append(deleted, record)
+	}
+
+	return deleted, nil
+}
+
+func isACMEChallenge(record Record) bool {
+	if record.Type != "TXT" {
+		return false
+	}
+
+	return record.Name == acmeChallengeLabel || strings.HasPrefix(record.Name, acmeChallengeLabel+".")
+}
+
+func parseACMEChallengeNotes(notes string) (time.Time, bool) {
+	value, ok := strings.CutPrefix(notes, acmeNotesPrefix)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	createdAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return createdAt, true
+}
@@ -0,0 +1,46 @@
+package porkbun
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestACMEChallengeNotes(t *testing.T) {
+	createdAt := time.Date(2020, 1, 1, 1, 0, 0, 0, time.FixedZone("CET", 3600))
+
+	notes := ACMEChallengeNotes(createdAt)
+	assert.Equal(t, "acme-challenge created=2020-01-01T00:00:00Z", notes)
+
+	parsed, ok := parseACMEChallengeNotes(notes)
+	require.True(t, ok)
+	assert.True(t, createdAt.Equal(parsed))
+}
+
+func TestClient_CleanupStaleACME(t *testing.T) {
+	client, mux := setupMux(t)
+
+	mux.HandleFunc("/dns/retrieve/example.com", fixtureHandler("retrieve-acme"))
+
+	var deletedIDs []string
+
+	mux.HandleFunc("/dns/delete/example.com/", func(rw http.ResponseWriter, req *http.Request) {
+		deletedIDs = append(deletedIDs, req.URL.Path[len("/dns/delete/example.com/"):])
+
+		_, _ = rw.Write([]byte(`{"status":"SUCCESS"}`))
+	})
+
+	WithClock(&fakeClock{now: time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)})(client)
+
+	deleted, err := client.CleanupStaleACME(context.Background(), "example.com", 365*24*time.Hour)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"106926653"}, deletedIDs)
+
+	require.Len(t, deleted, 1)
+	assert.Equal(t, "old", deleted[0].Content)
+}
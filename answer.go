@@ -0,0 +1,167 @@
+package porkbun
+
+import "strings"
+
+// maxCNAMEChain the maximum length of a CNAME chain followed by EffectiveAnswer.
+const maxCNAMEChain = 8
+
+// Answer the answer computed by EffectiveAnswer.
+type Answer struct {
+	// Records the records of the answer, in resolution order (CNAME records first).
+	// The records synthesized from a wildcard are named after the query name.
+	Records []Record
+	// Wildcard is true when a wildcard record was used.
+	Wildcard bool
+	// External the name outside the zone where the resolution continues (CNAME or ALIAS target), if any.
+	// For an ALIAS, the authoritative servers flatten the answer: the A/AAAA records of this name are returned under the query name.
+	External string
+	// NXDomain is true when the name doesn't exist in the zone.
+	NXDomain bool
+}
+
+// EffectiveAnswer computes the answer of the authoritative servers of the zone of domain to a query for name and qtype,
+// given the records of the zone (as returned by RetrieveRecords, with fully qualified names).
+// It applies the wildcard records, follows the CNAME chains inside the zone, and flattens the ALIAS records.
+// The CNAME and ALIAS chains are followed up to 8 names, loops stop the resolution.
+func EffectiveAnswer(zone []Record, domain, name, qtype string) Answer {
+	nodes := map[string][]Record{}
+	for _, record := range zone {
+		owner := normalizeName(record.Name)
+		nodes[owner] = append(nodes[owner], record)
+	}
+
+	apex := normalizeName(domain)
+
+	qtype = strings.ToUpper(qtype)
+
+	answer := Answer{}
+
+	seen := map[string]bool{}
+
+	current := normalizeName(name)
+
+	// flattenOwner the owner of the ALIAS record being flattened, if any:
+	// the records found are returned under this name, the intermediate CNAME records are hidden.
+	var flattenOwner string
+
+	for len(seen) < maxCNAMEChain {
+		seen[current] = true
+
+		records, wildcard, ok := lookupNode(nodes, current)
+		if !ok {
+			answer.NXDomain = flattenOwner == ""
+			return answer
+		}
+
+		if flattenOwner == "" {
+			answer.Wildcard = answer.Wildcard || wildcard
+		}
+
+		if matches := filterType(records, qtype, current); len(matches) > 0 {
+			for _, match := range matches {
+				if flattenOwner != "" {
+					match.Name = flattenOwner
+				}
+
+				answer.Records = append(answer.Records, match)
+			}
+
+			return answer
+		}
+
+		var target string
+
+		switch alias, cname := filterType(records, "ALIAS", current), filterType(records, "CNAME", current); {
+		case (qtype == "A" || qtype == "AAAA") && len(alias) > 0:
+			if flattenOwner == "" {
+				flattenOwner = current
+			}
+
+			target = normalizeName(alias[0].Content)
+
+		case len(cname) > 0 && qtype != "CNAME":
+			if flattenOwner == "" {
+				answer.Records = append(answer.Records, cname[0])
+			}
+
+			target = normalizeName(cname[0].Content)
+
+		default:
+			return answer
+		}
+
+		if seen[target] {
+			return answer
+		}
+
+		if !inZone(apex, target) {
+			answer.External = target
+			return answer
+		}
+
+		current = target
+	}
+
+	return answer
+}
+
+// lookupNode returns the records of a name, or the records of the wildcard matching the name.
+func lookupNode(nodes map[string][]Record, name string) ([]Record, bool, bool) {
+	if records, ok := nodes[name]; ok {
+		return records, false, true
+	}
+
+	if hasDescendants(nodes, name) {
+		// empty non-terminal: the name exists without records.
+		return nil, false, true
+	}
+
+	// the wildcard of the closest encloser applies (RFC 4592).
+	for parent := parentName(name); parent != ""; parent = parentName(parent) {
+		if records, ok := nodes["*."+parent]; ok {
+			return records, true, true
+		}
+
+		if _, ok := nodes[parent]; ok || hasDescendants(nodes, parent) {
+			return nil, false, false
+		}
+	}
+
+	return nil, false, false
+}
+
+func filterType(records []Record, qtype, owner string) []Record {
+	var matches []Record
+
+	for _, record := range records {
+		if strings.EqualFold(record.Type, qtype) {
+			record.Name = owner
+			matches = append(matches, record)
+		}
+	}
+
+	return matches
+}
+
+func inZone(apex, name string) bool {
+	return apex != "" && (name == apex || strings.HasSuffix(name, "."+apex))
+}
+
+func hasDescendants(nodes map[string][]Record, name string) bool {
+	for owner := range nodes {
+		if strings.HasSuffix(owner, "."+name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parentName(name string) string {
+	_, parent, _ := strings.Cut(name, ".")
+	return parent
+}
+
+func normalizeName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
@@ -0,0 +1,190 @@
+package porkbun
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEffectiveAnswer(t *testing.T) {
+	zone := []Record{
+		{Name: "example.com", Type: "A", Content: "203.0.113.1"},
+		{Name: "example.com", Type: "MX", Content: "mail.example.com", Prio: "10"},
+		{Name: "www.example.com", Type: "CNAME", Content: "example.com"},
+		{Name: "*.example.com", Type: "A", Content: "203.0.113.9"},
+		{Name: "b.sub.example.com", Type: "A", Content: "203.0.113.2"},
+		{Name: "cdn.example.com", Type: "CNAME", Content: "cdn.provider.net"},
+		{Name: "app.example.com", Type: "ALIAS", Content: "www.example.com"},
+		{Name: "ext.example.com", Type: "ALIAS", Content: "lb.provider.net"},
+		{Name: "loop1.example.com", Type: "CNAME", Content: "loop2.example.com"},
+		{Name: "loop2.example.com", Type: "CNAME", Content: "loop1.example.com"},
+		{Name: "alias1.example.com", Type: "ALIAS", Content: "alias2.example.com"},
+		{Name: "alias2.example.com", Type: "ALIAS", Content: "alias1.example.com"},
+		{Name: "mixed.example.com", Type: "ALIAS", Content: "loop1.example.com"},
+	}
+
+	testCases := []struct {
+		desc     string
+		name     string
+		qtype    string
+		expected Answer
+	}{
+		{
+			desc:  "direct",
+			name:  "example.com",
+			qtype: "A",
+			expected: Answer{Records: []Record{
+				{Name: "example.com", Type: "A", Content: "203.0.113.1"},
+			}},
+		},
+		{
+			desc:  "case and trailing dot",
+			name:  "Example.COM.",
+			qtype: "mx",
+			expected: Answer{Records: []Record{
+				{Name: "example.com", Type: "MX", Content: "mail.example.com", Prio: "10"},
+			}},
+		},
+		{
+			desc:  "CNAME chain",
+			name:  "www.example.com",
+			qtype: "A",
+			expected: Answer{Records: []Record{
+				{Name: "www.example.com", Type: "CNAME", Content: "example.com"},
+				{Name: "example.com", Type: "A", Content: "203.0.113.1"},
+			}},
+		},
+		{
+			desc:  "CNAME query",
+			name:  "www.example.com",
+			qtype: "CNAME",
+			expected: Answer{Records: []Record{
+				{Name: "www.example.com", Type: "CNAME", Content: "example.com"},
+			}},
+		},
+		{
+			desc:  "wildcard",
+			name:  "foo.bar.example.com",
+			qtype: "A",
+			expected: Answer{
+				Records: []Record{
+					{Name: "foo.bar.example.com", Type: "A", Content: "203.0.113.9"},
+				},
+				Wildcard: true,
+			},
+		},
+		{
+			desc:     "wildcard without the type",
+			name:     "foo.example.com",
+			qtype:    "TXT",
+			expected: Answer{Wildcard: true},
+		},
+		{
+			desc:     "wildcard blocked by an empty non-terminal",
+			name:     "a.sub.example.com",
+			qtype:    "A",
+			expected: Answer{NXDomain: true},
+		},
+		{
+			desc:     "empty non-terminal",
+			name:     "sub.example.com",
+			qtype:    "A",
+			expected: Answer{},
+		},
+		{
+			desc:  "CNAME outside the zone",
+			name:  "cdn.example.com",
+			qtype: "A",
+			expected: Answer{
+				Records: []Record{
+					{Name: "cdn.example.com", Type: "CNAME", Content: "cdn.provider.net"},
+				},
+				External: "cdn.provider.net",
+			},
+		},
+		{
+			desc:  "ALIAS flattening",
+			name:  "app.example.com",
+			qtype: "A",
+			expected: Answer{Records: []Record{
+				{Name: "app.example.com", Type: "A", Content: "203.0.113.1"},
+			}},
+		},
+		{
+			desc:     "ALIAS outside the zone",
+			name:     "ext.example.com",
+			qtype:    "AAAA",
+			expected: Answer{External: "lb.provider.net"},
+		},
+		{
+			desc:     "ALIAS not flattened for other types",
+			name:     "ext.example.com",
+			qtype:    "TXT",
+			expected: Answer{},
+		},
+		{
+			desc:  "CNAME loop",
+			name:  "loop1.example.com",
+			qtype: "A",
+			expected: Answer{Records: []Record{
+				{Name: "loop1.example.com", Type: "CNAME", Content: "loop2.example.com"},
+				{Name: "loop2.example.com", Type: "CNAME", Content: "loop1.example.com"},
+			}},
+		},
+		{
+			desc:     "ALIAS loop",
+			name:     "alias1.example.com",
+			qtype:    "A",
+			expected: Answer{},
+		},
+		{
+			desc:     "ALIAS to a CNAME loop",
+			name:     "mixed.example.com",
+			qtype:    "AAAA",
+			expected: Answer{},
+		},
+		{
+			desc:     "outside the zone",
+			name:     "example.net",
+			qtype:    "A",
+			expected: Answer{NXDomain: true},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			answer := EffectiveAnswer(zone, "example.com", test.name, test.qtype)
+
+			assert.Equal(t, test.expected, answer)
+		})
+	}
+}
+
+func TestEffectiveAnswer_subZone(t *testing.T) {
+	zone := []Record{
+		{Name: "www.sub.example.com", Type: "CNAME", Content: "example.com"},
+		{Name: "api.sub.example.com", Type: "CNAME", Content: "www.sub.example.com"},
+	}
+
+	answer := EffectiveAnswer(zone, "example.com", "www.sub.example.com", "A")
+
+	expected := Answer{
+		Records: []Record{
+			{Name: "www.sub.example.com", Type: "CNAME", Content: "example.com"},
+		},
+	}
+
+	assert.Equal(t, expected, answer)
+}
+
+func TestEffectiveAnswer_longChain(t *testing.T) {
+	var zone []Record
+	for i := 0; i < 20; i++ {
+		zone = append(zone, Record{Name: fmt.Sprintf("a%d.example.com", i), Type: "ALIAS", Content: fmt.Sprintf("a%d.example.com", i+1)})
+	}
+
+	answer := EffectiveAnswer(zone, "example.com", "a0.example.com", "A")
+
+	assert.Equal(t, Answer{}, answer)
+}
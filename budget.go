@@ -0,0 +1,46 @@
+package porkbun
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+)
+
+// CallBudgetExceededError is returned when the API call budget of a context is exhausted.
+type CallBudgetExceededError struct {
+	Limit int64
+}
+
+func (e CallBudgetExceededError) Error() string {
+	return fmt.Sprintf("porkbun: API call budget of %d calls exceeded", e.Limit)
+}
+
+type callBudgetKey struct{}
+
+type callBudget struct {
+	limit  int64
+	used   atomic.Int64
+	parent *callBudget
+}
+
+// WithCallBudget returns a context allowing at most n API calls.
+// The calls beyond the budget fail with a CallBudgetExceededError without calling the API.
+// The calls also count against the budgets of the parent contexts.
+func WithCallBudget(ctx context.Context, n int64) context.Context {
+	parent, _ := ctx.Value(callBudgetKey{}).(*callBudget)
+
+	return context.WithValue(ctx, callBudgetKey{}, &callBudget{limit: n, parent: parent})
+}
+
+// consumeCallBudget consumes one call of the budgets of the context.
+func consumeCallBudget(ctx context.Context) error {
+	budget, _ := ctx.Value(callBudgetKey{}).(*callBudget)
+
+	for b := budget; b != nil; b = b.parent {
+		if b.used.Add(1) > b.limit {
+			return &CallBudgetExceededError{Limit: b.limit}
+		}
+	}
+
+	return nil
+}
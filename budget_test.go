@@ -0,0 +1,44 @@
+package porkbun
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithCallBudget(t *testing.T) {
+	client := setup(t, "/ping", "ping")
+
+	ctx := WithCallBudget(context.Background(), 2)
+
+	for i := 0; i < 2; i++ {
+		_, err := client.Ping(ctx)
+		require.NoError(t, err)
+	}
+
+	_, err := client.Ping(ctx)
+
+	budgetErr := &CallBudgetExceededError{}
+	require.ErrorAs(t, err, &budgetErr)
+	assert.Equal(t, int64(2), budgetErr.Limit)
+
+	_, err = client.Ping(context.Background())
+	require.NoError(t, err)
+}
+
+func TestWithCallBudget_nested(t *testing.T) {
+	client := setup(t, "/ping", "ping")
+
+	ctx := WithCallBudget(context.Background(), 1)
+
+	_, err := client.Ping(WithCallBudget(ctx, 5))
+	require.NoError(t, err)
+
+	_, err = client.Ping(WithCallBudget(ctx, 5))
+
+	budgetErr := &CallBudgetExceededError{}
+	require.ErrorAs(t, err, &budgetErr)
+	assert.Equal(t, int64(1), budgetErr.Limit)
+}
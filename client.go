@@ -5,18 +5,31 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
-	"strconv"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
 	"time"
 )
 
 const defaultBaseURL = "https://api.porkbun.com/api/json/v3/"
 
+// DefaultAPIVersion the version of the API used by the default BaseURL.
+const DefaultAPIVersion = "v3"
+
 const statusSuccess = "SUCCESS"
 
+// ErrRecordNotFound is returned when a record doesn't exist.
+var ErrRecordNotFound = errors.New("porkbun: record not found")
+
+// ErrPrioRequired is returned when a SRV record is created or edited without priority.
+var ErrPrioRequired = errors.New("porkbun: the priority of a SRV record is required")
+
 // DefaultTTL The minimum and the default is 300 seconds.
 const DefaultTTL = "300"
 
@@ -25,53 +38,97 @@ type Client struct {
 	secretAPIKey string
 	apiKey       string
 
+	// BaseURL the prefix of the API endpoints, including the version (ex: "https://api.porkbun.com/api/json/v3/").
+	// A version set with WithAPIVersion replaces the trailing version segment.
 	BaseURL    *url.URL
 	HTTPClient *http.Client
+
+	// SortRecords sorts the records returned by RetrieveRecords by name, type, and content.
+	// The order of the records returned by the API is not stable.
+	// It can be set with WithSortedRecords.
+	SortRecords bool
+
+	readOnly    bool
+	safeguards  bool
+	lintHandler LintHandler
+
+	deleteVerification deleteVerification
+
+	apiVersion       string
+	endpointVersions map[string]string
+
+	usage usageTracker
+
+	status statusTracker
+
+	clock Clock
+
+	recordsCache    recordsCache
+	notLocalDomains domainSet
+
+	resolver Resolver
 }
 
 // New creates a new Client.
-func New(secretAPIKey, apiKey string) *Client {
+func New(secretAPIKey, apiKey string, opts ...Option) *Client {
 	baseURL, _ := url.Parse(defaultBaseURL)
 
-	return &Client{
+	client := &Client{
 		secretAPIKey: secretAPIKey,
 		apiKey:       apiKey,
 		BaseURL:      baseURL,
 		HTTPClient:   &http.Client{Timeout: 10 * time.Second},
+		recordsCache: recordsCache{ttl: defaultRecordsCacheTTL},
+		usage:        usageTracker{window: defaultUsageWindow},
+	}
+
+	for _, opt := range opts {
+		opt(client)
 	}
+
+	return client
 }
 
 // Ping tests communication with the API.
 func (c *Client) Ping(ctx context.Context) (string, error) {
-	endpoint := c.BaseURL.JoinPath("ping")
+	return c.ping(ctx, c.HTTPClient)
+}
 
-	respBody, err := c.do(ctx, endpoint, nil)
+// CreateRecord creates a DNS record.
+//
+//	name (optional): The subdomain for the record being created, not including the domain itself. Leave blank to create a record on the root domain. Use * to create a wildcard record.
+//	type: The type of record being created. Valid types are: A, MX, CNAME, ALIAS, TXT, NS, AAAA, SRV, TLSA, CAA
+//	content: The answer content for the record. For TXT records, the unquoted value (see EncodeTXT).
+//	ttl (optional): The time to live in seconds for the record. The minimum and the default is 300 seconds.
+//	prio (optional) The priority of the record for those that support it. Defaults to 0 for MX records, required for SRV records.
+func (c *Client) CreateRecord(ctx context.Context, domain string, record Record) (RecordID, error) {
+	if c.readOnly {
+		return 0, ErrReadOnly
+	}
+
+	err := c.checkLocal(domain)
 	if err != nil {
-		return "", err
+		return 0, err
 	}
 
-	pingResp := pingResponse{}
-	err = json.Unmarshal(respBody, &pingResp)
+	record, err = defaultPrio(record)
 	if err != nil {
-		return "", fmt.Errorf("failed to unmarshal response: %w", err)
+		return 0, err
 	}
 
-	if pingResp.Status.Status != statusSuccess {
-		return "", pingResp.Status
+	record = encodeTXTRecord(record)
+
+	err = c.guardRecord(ctx, domain, record)
+	if err != nil {
+		return 0, err
 	}
 
-	return pingResp.YourIP, nil
-}
+	err = c.lint(domain, record)
+	if err != nil {
+		return 0, err
+	}
 
-// CreateRecord creates a DNS record.
-//
-//	name (optional): The subdomain for the record being created, not including the domain itself. Leave blank to create a record on the root domain. Use * to create a wildcard record.
-//	type: The type of record being created. Valid types are: A, MX, CNAME, ALIAS, TXT, NS, AAAA, SRV, TLSA, CAA
-//	content: The answer content for the record.
-//	ttl (optional): The time to live in seconds for the record. The minimum and the default is 300 seconds.
-//	prio (optional) The priority of the record for those that support it.
-func (c *Client) CreateRecord(ctx context.Context, domain string, record Record) (int, error) {
-	endpoint := c.BaseURL.JoinPath("dns", "create", domain)
+	endpoint := c.endpoint("dns/create", domain)
 
 	respBody, err := c.do(ctx, endpoint, record)
 	if err != nil {
@@ -88,6 +145,8 @@ func (c *Client) CreateRecord(ctx context.Context, domain string, record Record)
 		return 0, createResp.Status
 	}
 
+	c.recordsCache.invalidate(domain)
+
 	return createResp.ID, nil
 }
 
@@ -95,13 +154,54 @@ func (c *Client) CreateRecord(ctx context.Context, domain string, record Record)
 //
 //	name (optional): The subdomain for the record being created, not including the domain itself. Leave blank to create a record on the root domain. Use * to create a wildcard record.
 //	type: The type of record being created. Valid types are: A, MX, CNAME, ALIAS, TXT, NS, AAAA, SRV, TLSA, CAA
-//	content: The answer content for the record.
+//	content: The answer content for the record. For TXT records, the unquoted value (see EncodeTXT).
 //	ttl (optional): The time to live in seconds for the record. The minimum and the default is 300 seconds.
-//	prio (optional) The priority of the record for those that support it.
-func (c *Client) EditRecord(ctx context.Context, domain string, id int, record Record) error {
-	endpoint := c.BaseURL.JoinPath("dns", "edit", domain, strconv.Itoa(id))
+//	prio (optional) The priority of the record for those that support it. Defaults to 0 for MX records, required for SRV records.
+func (c *Client) EditRecord(ctx context.Context, domain string, id RecordID, record Record) error {
+	return c.editRecord(ctx, domain, id, record, nil)
+}
 
-	respBody, err := c.do(ctx, endpoint, record)
+// editRecord edits a DNS record, the fields provided by the patch are sent even when empty.
+func (c *Client) editRecord(ctx context.Context, domain string, id RecordID, record Record, patch *RecordPatch) error {
+	if c.readOnly {
+		return ErrReadOnly
+	}
+
+	err := c.checkLocal(domain)
+	if err != nil {
+		return err
+	}
+
+	record, err = defaultPrio(record)
+	if err != nil {
+		return err
+	}
+
+	record = encodeTXTRecord(record)
+
+	err = c.guardRecord(ctx, domain, record)
+	if err != nil {
+		return err
+	}
+
+	err = c.guardExistingRecord(ctx, domain, id)
+	if err != nil {
+		return err
+	}
+
+	err = c.lint(domain, record)
+	if err != nil {
+		return err
+	}
+
+	endpoint := c.endpoint("dns/edit", domain, id.String())
+
+	var request interface{} = record
+	if patch != nil {
+		request = newPatchRequest(record, *patch)
+	}
+
+	respBody, err := c.do(ctx, endpoint, request)
 	if err != nil {
 		return err
 	}
@@ -116,34 +216,50 @@ func (c *Client) EditRecord(ctx context.Context, domain string, id int, record R
 		return statusResp
 	}
 
+	c.recordsCache.invalidate(domain)
+
 	return nil
 }
 
 // DeleteRecord deletes a specific DNS record.
-func (c *Client) DeleteRecord(ctx context.Context, domain string, id int) error {
-	endpoint := c.BaseURL.JoinPath("dns", "delete", domain, strconv.Itoa(id))
+// With WithDeleteVerification, the deletion is verified and retried while the record persists.
+func (c *Client) DeleteRecord(ctx context.Context, domain string, id RecordID) error {
+	if c.readOnly {
+		return ErrReadOnly
+	}
 
-	respBody, err := c.do(ctx, endpoint, nil)
+	err := c.checkLocal(domain)
 	if err != nil {
 		return err
 	}
 
-	statusResp := Status{}
-	err = json.Unmarshal(respBody, &statusResp)
+	err = c.guardExistingRecord(ctx, domain, id)
 	if err != nil {
-		return fmt.Errorf("failed to unmarshal response: %w", err)
+		return err
 	}
 
-	if statusResp.Status != statusSuccess {
-		return statusResp
+	err = c.deleteRecord(ctx, domain, id)
+	if err != nil {
+		return err
+	}
+
+	if c.deleteVerification.attempts > 0 {
+		return c.verifyDeletion(ctx, domain, id)
 	}
 
 	return nil
 }
 
 // RetrieveRecords retrieve all editable DNS records associated with a domain.
+// The records are sorted by name, type, and content when Client.SortRecords is enabled,
+// otherwise they are returned in the order of the API response, which is not stable.
 func (c *Client) RetrieveRecords(ctx context.Context, domain string) ([]Record, error) {
-	endpoint := c.BaseURL.JoinPath("dns", "retrieve", domain)
+	err := c.checkLocal(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := c.endpoint("dns/retrieve", domain)
 
 	respBody, err := c.do(ctx, endpoint, nil)
 	if err != nil {
@@ -160,12 +276,46 @@ func (c *Client) RetrieveRecords(ctx context.Context, domain string) ([]Record,
 		return nil, retrieveResp.Status
 	}
 
+	decodeTXTRecords(retrieveResp.Records)
+
+	if c.SortRecords {
+		sortRecords(retrieveResp.Records)
+	}
+
 	return retrieveResp.Records, nil
 }
 
+// PatchRecord updates only the provided fields of a DNS record.
+// The current record is retrieved and merged with the patch,
+// then the full record is sent to the API, so the fields not provided keep their values.
+// The fields provided with an empty value are sent to clear them (ex: the notes).
+func (c *Client) PatchRecord(ctx context.Context, domain string, id RecordID, patch RecordPatch) error {
+	if c.readOnly {
+		return ErrReadOnly
+	}
+
+	current, err := c.retrieveRecord(ctx, domain, id)
+	if err != nil {
+		return err
+	}
+
+	record := Record{
+		Name:    subDomain(current.Name, domain),
+		Type:    current.Type,
+		Content: current.Content,
+		TTL:     current.TTL,
+		Prio:    current.Prio,
+		Notes:   current.Notes,
+	}
+
+	patch.apply(&record)
+
+	return c.editRecord(ctx, domain, id, record, &patch)
+}
+
 // RetrieveSSLBundle retrieve the SSL certificate bundle for the domain.
 func (c *Client) RetrieveSSLBundle(ctx context.Context, domain string) (SSLBundle, error) {
-	endpoint := c.BaseURL.JoinPath("ssl", "retrieve", domain)
+	endpoint := c.endpoint("ssl/retrieve", domain)
 
 	respBody, err := c.do(ctx, endpoint, nil)
 	if err != nil {
@@ -185,7 +335,131 @@ func (c *Client) RetrieveSSLBundle(ctx context.Context, domain string) (SSLBundl
 	return bundleResp.SSLBundle, nil
 }
 
-func (c *Client) do(ctx context.Context, endpoint *url.URL, apiRequest interface{}) ([]byte, error) {
+func (c *Client) deleteRecord(ctx context.Context, domain string, id RecordID) error {
+	endpoint := c.endpoint("dns/delete", domain, id.String())
+
+	respBody, err := c.do(ctx, endpoint, nil)
+	if err != nil {
+		return err
+	}
+
+	statusResp := Status{}
+	err = json.Unmarshal(respBody, &statusResp)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	if statusResp.Status != statusSuccess {
+		return statusResp
+	}
+
+	c.recordsCache.invalidate(domain)
+
+	return nil
+}
+
+func (c *Client) retrieveRecord(ctx context.Context, domain string, id RecordID) (Record, error) {
+	err := c.checkLocal(domain)
+	if err != nil {
+		return Record{}, err
+	}
+
+	endpoint := c.endpoint("dns/retrieve", domain, id.String())
+
+	respBody, err := c.do(ctx, endpoint, nil)
+	if err != nil {
+		return Record{}, err
+	}
+
+	retrieveResp := retrieveResponse{}
+	err = json.Unmarshal(respBody, &retrieveResp)
+	if err != nil {
+		return Record{}, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	if retrieveResp.Status.Status != statusSuccess {
+		return Record{}, retrieveResp.Status
+	}
+
+	if len(retrieveResp.Records) == 0 {
+		return Record{}, fmt.Errorf("record %s: %w", id, ErrRecordNotFound)
+	}
+
+	decodeTXTRecords(retrieveResp.Records)
+
+	return retrieveResp.Records[0], nil
+}
+
+func (c *Client) ping(ctx context.Context, httpClient *http.Client) (string, error) {
+	endpoint := c.endpoint("ping")
+
+	respBody, err := c.doWith(ctx, httpClient, endpoint, nil)
+	if err != nil {
+		return "", err
+	}
+
+	pingResp := pingResponse{}
+	err = json.Unmarshal(respBody, &pingResp)
+	if err != nil {
+		return "", fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	if pingResp.Status.Status != statusSuccess {
+		return "", pingResp.Status
+	}
+
+	return pingResp.YourIP, nil
+}
+
+// endpoint builds the URL of an API endpoint: <BaseURL>/<name>/<params>.
+// The name identifies the endpoint (ex: "dns/create"), its version can be set independently with WithAPIVersion.
+func (c *Client) endpoint(name string, params ...string) apiEndpoint {
+	version := c.apiVersion
+	if v, ok := c.endpointVersions[name]; ok {
+		version = v
+	}
+
+	baseURL := c.BaseURL
+	if version != "" {
+		baseURL = withVersion(c.BaseURL, version)
+	}
+
+	return apiEndpoint{
+		name: name,
+		url:  baseURL.JoinPath(append([]string{name}, params...)...),
+	}
+}
+
+var versionSegment = regexp.MustCompile(`^v\d+$`)
+
+// withVersion replaces the trailing version segment of a URL (ex: "/api/json/v3/"),
+// the version is appended when the URL has no version segment.
+func withVersion(u *url.URL, version string) *url.URL {
+	p := strings.TrimSuffix(u.Path, "/")
+
+	if versionSegment.MatchString(path.Base(p)) {
+		p = path.Dir(p)
+	}
+
+	versioned := *u
+	versioned.Path = strings.TrimSuffix(p, "/") + "/" + version + "/"
+	versioned.RawPath = ""
+
+	return &versioned
+}
+
+func (c *Client) do(ctx context.Context, endpoint apiEndpoint, apiRequest interface{}) ([]byte, error) {
+	return c.doWith(ctx, c.HTTPClient, endpoint, apiRequest)
+}
+
+func (c *Client) doWith(ctx context.Context, httpClient *http.Client, endpoint apiEndpoint, apiRequest interface{}) ([]byte, error) {
+	err := consumeCallBudget(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c.usage.record(endpoint.name, c.getClock().Now())
+
 	request := authRequest{
 		APIKey:       c.apiKey,
 		SecretAPIKey: c.secretAPIKey,
@@ -197,12 +471,12 @@ func (c *Client) do(ctx context.Context, endpoint *url.URL, apiRequest interface
 		return nil, fmt.Errorf("failed to marshal request body: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), bytes.NewReader(reqBody))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.url.String(), bytes.NewReader(reqBody))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to call API: %w", err)
 	}
@@ -216,19 +490,61 @@ func (c *Client) do(ctx context.Context, endpoint *url.URL, apiRequest interface
 
 	switch resp.StatusCode {
 	case http.StatusOK:
+		c.status.success()
+
 		return respBody, nil
 
 	case http.StatusServiceUnavailable:
 		// related to https://github.com/nrdcg/porkbun/issues/5
-		return nil, &ServerError{
+		return nil, c.status.failure(ctx, &ServerError{
 			StatusCode: resp.StatusCode,
 			Message:    http.StatusText(http.StatusServiceUnavailable),
-		}
+		}, c.getClock().Now())
 
 	default:
-		return nil, &ServerError{
+		return nil, c.status.failure(ctx, &ServerError{
 			StatusCode: resp.StatusCode,
 			Message:    string(respBody),
+		}, c.getClock().Now())
+	}
+}
+
+// subDomain returns the subdomain part of a record name returned by the API (ex: "www.example.com" -> "www").
+func subDomain(name, domain string) string {
+	if name == domain {
+		return ""
+	}
+
+	return strings.TrimSuffix(name, "."+domain)
+}
+
+// sortRecords sorts the records by name, type, and content.
+func sortRecords(records []Record) {
+	slices.SortStableFunc(records, func(a, b Record) int {
+		if c := strings.Compare(a.Name, b.Name); c != 0 {
+			return c
+		}
+
+		if c := strings.Compare(a.Type, b.Type); c != 0 {
+			return c
 		}
+
+		return strings.Compare(a.Content, b.Content)
+	})
+}
+
+// defaultPrio applies the priority rules: the priority of a MX record defaults to 0, the priority of a SRV record is required.
+func defaultPrio(record Record) (Record, error) {
+	if record.Prio != "" {
+		return record, nil
 	}
+
+	switch strings.ToUpper(record.Type) {
+	case "MX":
+		record.Prio = "0"
+	case "SRV":
+		return record, ErrPrioRequired
+	}
+
+	return record, nil
 }
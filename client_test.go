@@ -2,15 +2,15 @@ package porkbun
 
 import (
 	"context"
-	"fmt"
+	"encoding/json"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
-	"os"
 	"strings"
 	"testing"
 
+	"github.com/nrdcg/porkbun/porkbuntest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -34,14 +34,7 @@ func setup(t *testing.T, pattern, filename string) *Client {
 			return
 		}
 
-		data, err := os.ReadFile(fmt.Sprintf("./fixtures/%s.json", filename))
-		if err != nil {
-			http.Error(rw, err.Error(), http.StatusInternalServerError)
-			return
-		}
-
-		rw.Header().Set("Content-Type", "application/json")
-		_, _ = rw.Write(data)
+		fixtureHandler(filename)(rw, req)
 	})
 
 	client := New("secret", "key")
@@ -50,6 +43,30 @@ func setup(t *testing.T, pattern, filename string) *Client {
 	return client
 }
 
+func setupMux(t *testing.T) (*Client, *http.ServeMux) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("secret", "key")
+	client.BaseURL, _ = url.Parse(server.URL)
+
+	return client, mux
+}
+
+// loadFixture loads a fixture, the test fails if the fixture doesn't exist.
+func loadFixture(t *testing.T, filename string) []byte {
+	t.Helper()
+
+	return porkbuntest.LoadFixture(t, filename)
+}
+
+func fixtureHandler(filename string) http.HandlerFunc {
+	return porkbuntest.FixtureHandler(filename)
+}
+
 func TestClient_Ping(t *testing.T) {
 	client := setup(t, "/ping", "ping")
 
@@ -59,6 +76,16 @@ func TestClient_Ping(t *testing.T) {
 	assert.Equal(t, "2a02:842b:5da:c101:4b81:e1b5:83f7:3e7c", ping)
 }
 
+func TestClient_Ping_baseURL(t *testing.T) {
+	client, mux := setupMux(t)
+	client.BaseURL = client.BaseURL.JoinPath("porkbun", "/")
+
+	mux.HandleFunc("/porkbun/ping", fixtureHandler("ping"))
+
+	_, err := client.Ping(context.Background())
+	require.NoError(t, err)
+}
+
 func TestClient_Ping_error(t *testing.T) {
 	client := setup(t, "/ping", "error")
 
@@ -79,7 +106,16 @@ func TestClient_CreateRecord(t *testing.T) {
 	id, err := client.CreateRecord(context.Background(), "example.com", record)
 	require.NoError(t, err)
 
-	assert.Equal(t, 106926659, id)
+	assert.Equal(t, RecordID(106926659), id)
+}
+
+func TestClient_CreateRecord_stringID(t *testing.T) {
+	client := setup(t, "/dns/create/example.com", "create-string-id")
+
+	id, err := client.CreateRecord(context.Background(), "example.com", Record{Type: "TXT", Content: "foobar"})
+	require.NoError(t, err)
+
+	assert.Equal(t, RecordID(106926659), id)
 }
 
 func TestClient_CreateRecord_error(t *testing.T) {
@@ -96,6 +132,69 @@ func TestClient_CreateRecord_error(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestClient_CreateRecord_prio(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		record   Record
+		expected string
+	}{
+		{
+			desc:     "MX without priority",
+			record:   Record{Type: "MX", Content: "mail.example.com"},
+			expected: "0",
+		},
+		{
+			desc:     "MX with priority",
+			record:   Record{Type: "MX", Content: "mail.example.com", Prio: "10"},
+			expected: "10",
+		},
+		{
+			desc:     "SRV with priority",
+			record:   Record{Name: "_sip._tcp", Type: "SRV", Content: "5 5060 sip.example.com", Prio: "10"},
+			expected: "10",
+		},
+		{
+			desc:   "A without priority",
+			record: Record{Type: "A", Content: "1.1.1.1"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			client, mux := setupMux(t)
+
+			var created Record
+
+			mux.HandleFunc("/dns/create/example.com", func(rw http.ResponseWriter, req *http.Request) {
+				err := json.NewDecoder(req.Body).Decode(&created)
+				if err != nil {
+					http.Error(rw, err.Error(), http.StatusBadRequest)
+					return
+				}
+
+				fixtureHandler("create")(rw, req)
+			})
+
+			_, err := client.CreateRecord(context.Background(), "example.com", test.record)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, created.Prio)
+		})
+	}
+}
+
+func TestClient_CreateRecord_prioRequired(t *testing.T) {
+	client := setup(t, "/dns/create/example.com", "create")
+
+	record := Record{Name: "_sip._tcp", Type: "SRV", Content: "5 5060 sip.example.com"}
+
+	_, err := client.CreateRecord(context.Background(), "example.com", record)
+	require.ErrorIs(t, err, ErrPrioRequired)
+
+	err = client.EditRecord(context.Background(), "example.com", 666, record)
+	require.ErrorIs(t, err, ErrPrioRequired)
+}
+
 func TestClient_EditRecord(t *testing.T) {
 	client := setup(t, "/dns/edit/example.com/666", "edit")
 
@@ -165,7 +264,7 @@ func TestClient_RetrieveRecords(t *testing.T) {
 
 	expected := []Record{
 		{
-			ID:      "106926652",
+			ID:      106926652,
 			Name:    "borseth.ink",
 			Type:    "A",
 			Content: "1.1.1.1",
@@ -174,7 +273,7 @@ func TestClient_RetrieveRecords(t *testing.T) {
 			Notes:   "",
 		},
 		{
-			ID:      "106926659",
+			ID:      106926659,
 			Name:    "www.borseth.ink",
 			Type:    "A",
 			Content: "1.1.1.1",
@@ -187,6 +286,62 @@ func TestClient_RetrieveRecords(t *testing.T) {
 	assert.Equal(t, expected, records)
 }
 
+func TestClient_RetrieveRecords_sorted(t *testing.T) {
+	client := setup(t, "/dns/retrieve/example.com", "retrieve-acme")
+	client.SortRecords = true
+
+	records, err := client.RetrieveRecords(context.Background(), "example.com")
+	require.NoError(t, err)
+
+	var ids []string
+	for _, record := range records {
+		ids = append(ids, record.ID.String())
+	}
+
+	assert.Equal(t, []string{"106926655", "106926653", "106926654", "106926652"}, ids)
+}
+
+func TestClient_RetrieveRecords_quirks(t *testing.T) {
+	client := setup(t, "/dns/retrieve/example.com", "retrieve-quirks")
+
+	records, err := client.RetrieveRecords(context.Background(), "example.com")
+	require.NoError(t, err)
+
+	expected := []Record{
+		{
+			ID:      106926652,
+			Name:    "example.com",
+			Type:    "A",
+			Content: "1.1.1.1",
+			TTL:     "600",
+		},
+		{
+			ID:      106926659,
+			Name:    "example.com",
+			Type:    "MX",
+			Content: "mail.example.com",
+			TTL:     "300",
+			Prio:    "10",
+			Notes:   "mail",
+		},
+	}
+
+	assert.Equal(t, expected, records)
+}
+
+func TestClient_RetrieveRecords_empty(t *testing.T) {
+	for _, filename := range []string{"retrieve-empty", "retrieve-null"} {
+		t.Run(filename, func(t *testing.T) {
+			client := setup(t, "/dns/retrieve/example.com", filename)
+
+			records, err := client.RetrieveRecords(context.Background(), "example.com")
+			require.NoError(t, err)
+
+			assert.Empty(t, records)
+		})
+	}
+}
+
 func TestClient_RetrieveRecords_error(t *testing.T) {
 	client := setup(t, "/dns/retrieve/example.com", "error")
 
@@ -194,6 +349,70 @@ func TestClient_RetrieveRecords_error(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestClient_PatchRecord(t *testing.T) {
+	client, mux := setupMux(t)
+
+	mux.HandleFunc("/dns/retrieve/example.com/666", fixtureHandler("retrieve-id"))
+
+	var edited map[string]string
+
+	mux.HandleFunc("/dns/edit/example.com/666", func(rw http.ResponseWriter, req *http.Request) {
+		err := json.NewDecoder(req.Body).Decode(&edited)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		_, _ = rw.Write([]byte(`{"status":"SUCCESS"}`))
+	})
+
+	content := "2.2.2.2"
+
+	err := client.PatchRecord(context.Background(), "example.com", 666, RecordPatch{Content: &content})
+	require.NoError(t, err)
+
+	expected := map[string]string{
+		"apikey":       "key",
+		"secretapikey": "secret",
+		"name":         "www",
+		"type":         "A",
+		"content":      "2.2.2.2",
+		"ttl":          "600",
+		"prio":         "0",
+		"notes":        "web",
+	}
+
+	assert.Equal(t, expected, edited)
+}
+
+func TestClient_PatchRecord_clearNotes(t *testing.T) {
+	server := porkbuntest.NewServer(t)
+	server.AddZone("example.com", porkbuntest.Record{ID: "666", Name: "www.example.com", Type: "A", Content: "1.1.1.1", TTL: "600", Prio: "0", Notes: "web"})
+
+	client := New("secret", "key")
+	client.BaseURL, _ = url.Parse(server.URL)
+
+	empty := ""
+
+	err := client.PatchRecord(context.Background(), "example.com", 666, RecordPatch{Notes: &empty})
+	require.NoError(t, err)
+
+	expected := []porkbuntest.Record{
+		{ID: "666", Name: "www.example.com", Type: "A", Content: "1.1.1.1", TTL: "600", Prio: "0"},
+	}
+
+	assert.Equal(t, expected, server.Zone("example.com"))
+}
+
+func TestClient_PatchRecord_error(t *testing.T) {
+	client := setup(t, "/dns/retrieve/example.com/666", "error")
+
+	content := "2.2.2.2"
+
+	err := client.PatchRecord(context.Background(), "example.com", 666, RecordPatch{Content: &content})
+	require.Error(t, err)
+}
+
 func TestClient_RetrieveSSLBundle(t *testing.T) {
 	client := setup(t, "/ssl/retrieve/example.com", "ssl-bundle")
 
@@ -216,3 +435,36 @@ func TestClient_RetrieveSSLBundle_error(t *testing.T) {
 	_, err := client.RetrieveRecords(context.Background(), "example.com")
 	require.Error(t, err)
 }
+
+func TestFixtures(t *testing.T) {
+	responses := map[string]any{
+		"check-domain":     &checkDomainResponse{},
+		"create":           &createResponse{},
+		"create-string-id": &createResponse{},
+		"delete":           &Status{},
+		"edit":             &Status{},
+		"error":            &Status{},
+		"list-all":         &listAllResponse{},
+		"ping":             &pingResponse{},
+		"retrieve":         &retrieveResponse{},
+		"retrieve-empty":   &retrieveResponse{},
+		"retrieve-null":    &retrieveResponse{},
+		"retrieve-quirks":  &retrieveResponse{},
+		"retrieve-id":      &retrieveResponse{},
+		"retrieve-acme":    &retrieveResponse{},
+		"retrieve-srv":     &retrieveResponse{},
+		"retrieve-dmarc":   &retrieveResponse{},
+		"retrieve-ns":      &retrieveResponse{},
+		"ssl-bundle":       &sslBundleResponse{},
+	}
+
+	for _, filename := range porkbuntest.FixtureNames() {
+		t.Run(filename, func(t *testing.T) {
+			response, ok := responses[filename]
+			require.Truef(t, ok, "no response type for the fixture %s", filename)
+
+			err := json.Unmarshal(loadFixture(t, filename), response)
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package porkbun
+
+import "time"
+
+// Clock provides the time to the client: the cache expiration, the usage window, and the waits between retries.
+// A fake clock allows deterministic tests without sleeping.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// getClock returns the clock of the client, the real clock by default.
+func (c *Client) getClock() Clock {
+	if c.clock == nil {
+		return realClock{}
+	}
+
+	return c.clock
+}
@@ -0,0 +1,111 @@
+package porkbun
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock a clock where the waits return immediately and advance the time.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (f *fakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.now
+}
+
+func (f *fakeClock) After(d time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	ch <- f.Advance(d)
+
+	return ch
+}
+
+func (f *fakeClock) Advance(d time.Duration) time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.now = f.now.Add(d)
+
+	return f.now
+}
+
+func TestWithClock_recordsCache(t *testing.T) {
+	client, mux := setupMux(t)
+
+	clock := newFakeClock()
+	WithClock(clock)(client)
+
+	var calls int
+
+	mux.HandleFunc("/dns/retrieve/example.com", func(rw http.ResponseWriter, req *http.Request) {
+		calls++
+
+		fixtureHandler("retrieve")(rw, req)
+	})
+
+	ctx := context.Background()
+
+	_, err := client.RetrieveRecordsPage(ctx, "example.com", 0, 10, nil)
+	require.NoError(t, err)
+
+	clock.Advance(defaultRecordsCacheTTL - time.Second)
+
+	_, err = client.RetrieveRecordsPage(ctx, "example.com", 0, 10, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, calls)
+
+	clock.Advance(2 * time.Second)
+
+	_, err = client.RetrieveRecordsPage(ctx, "example.com", 0, 10, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, calls)
+}
+
+func TestWithClock_usage(t *testing.T) {
+	client := setup(t, "/ping", "ping")
+
+	clock := newFakeClock()
+	WithClock(clock)(client)
+
+	_, err := client.Ping(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, client.Usage().Total)
+
+	clock.Advance(defaultUsageWindow)
+
+	assert.Equal(t, 0, client.Usage().Total)
+}
+
+func TestWithClock_deleteVerification(t *testing.T) {
+	client, deletes := setupDeleteVerification(t, 2)
+
+	clock := newFakeClock()
+	WithClock(clock)(client)
+	WithDeleteVerification(5, time.Hour)(client)
+
+	start := clock.Now()
+
+	err := client.DeleteRecord(context.Background(), "example.com", 666)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, *deletes)
+	assert.Equal(t, 3*time.Hour, clock.Now().Sub(start))
+}
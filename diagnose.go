@@ -0,0 +1,251 @@
+package porkbun
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// maxClockSkew the clock skew beyond which Diagnose reports a warning.
+const maxClockSkew = time.Minute
+
+// DiagnosticStatus the status of a diagnostic check.
+type DiagnosticStatus string
+
+// Diagnostic statuses.
+const (
+	DiagnosticOK      DiagnosticStatus = "ok"
+	DiagnosticWarning DiagnosticStatus = "warning"
+	DiagnosticFailed  DiagnosticStatus = "failed"
+)
+
+// DiagnosticCheck the result of a diagnostic check.
+type DiagnosticCheck struct {
+	Name    string
+	Status  DiagnosticStatus
+	Message string
+}
+
+// Diagnosis the report of Diagnose.
+type Diagnosis struct {
+	Checks []DiagnosticCheck
+}
+
+// OK returns true when no check failed.
+func (d Diagnosis) OK() bool {
+	for _, check := range d.Checks {
+		if check.Status == DiagnosticFailed {
+			return false
+		}
+	}
+
+	return true
+}
+
+// String returns the report, one check per line.
+func (d Diagnosis) String() string {
+	var b strings.Builder
+
+	for _, check := range d.Checks {
+		_, _ = fmt.Fprintf(&b, "[%s] %s: %s\n", check.Status, check.Name, check.Message)
+	}
+
+	return b.String()
+}
+
+func (d *Diagnosis) add(name string, status DiagnosticStatus, format string, args ...interface{}) {
+	d.Checks = append(d.Checks, DiagnosticCheck{Name: name, Status: status, Message: fmt.Sprintf(format, args...)})
+}
+
+// Diagnose runs a set of checks to debug the setup of the client:
+// the reachability of the API (proxy and TLS), the clock skew with the API, the ping over IPv4 and IPv6,
+// the credentials, and the read of one zone.
+// The checks don't change anything.
+func (c *Client) Diagnose(ctx context.Context) Diagnosis {
+	diagnosis := Diagnosis{}
+
+	c.diagnoseReachability(ctx, &diagnosis)
+
+	credentials := false
+
+	for _, ping := range c.diagnosticPings(&diagnosis) {
+		ip, err := c.ping(ctx, ping.httpClient)
+
+		var status Status
+
+		switch {
+		case err == nil:
+			credentials = true
+			diagnosis.add(ping.name, DiagnosticOK, "your IP: %s", ip)
+		case errors.As(err, &status):
+			diagnosis.add("credentials", DiagnosticFailed, "%v", status)
+			return diagnosis
+		default:
+			diagnosis.add(ping.name, DiagnosticWarning, "%v", err)
+		}
+	}
+
+	if !credentials {
+		diagnosis.add("credentials", DiagnosticFailed, "the API cannot be reached")
+		return diagnosis
+	}
+
+	diagnosis.add("credentials", DiagnosticOK, "the API keys are valid")
+
+	c.diagnoseZone(ctx, &diagnosis)
+
+	return diagnosis
+}
+
+type diagnosticPing struct {
+	name       string
+	httpClient *http.Client
+}
+
+// diagnosticPings returns the pings over IPv4 and IPv6.
+// With a custom transport, the network cannot be restricted: the IPv4 and IPv6 pings are skipped,
+// and the API is pinged with the HTTP client of the client.
+func (c *Client) diagnosticPings(diagnosis *Diagnosis) []diagnosticPing {
+	var pings []diagnosticPing
+
+	for _, network := range []string{"tcp4", "tcp6"} {
+		name := "ping-ipv4"
+		if network == "tcp6" {
+			name = "ping-ipv6"
+		}
+
+		httpClient, ok := networkHTTPClient(c.HTTPClient, network)
+		if !ok {
+			diagnosis.add(name, DiagnosticWarning, "skipped: the HTTP client uses a custom transport")
+			continue
+		}
+
+		pings = append(pings, diagnosticPing{name: name, httpClient: httpClient})
+	}
+
+	if len(pings) == 0 {
+		pings = append(pings, diagnosticPing{name: "ping", httpClient: c.HTTPClient})
+	}
+
+	return pings
+}
+
+// diagnoseReachability checks the proxy, the TLS connection, and the clock skew with the API.
+func (c *Client) diagnoseReachability(ctx context.Context, diagnosis *Diagnosis) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.BaseURL.String(), http.NoBody)
+	if err != nil {
+		diagnosis.add("reachability", DiagnosticFailed, "%v", err)
+		return
+	}
+
+	proxy := "none"
+
+	if transport := httpTransport(c.HTTPClient); transport != nil && transport.Proxy != nil {
+		proxyURL, errP := transport.Proxy(req)
+		if errP != nil {
+			diagnosis.add("proxy", DiagnosticFailed, "%v", errP)
+			return
+		}
+
+		if proxyURL != nil {
+			proxy = proxyURL.Redacted()
+		}
+	}
+
+	diagnosis.add("proxy", DiagnosticOK, "proxy: %s", proxy)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		diagnosis.add("reachability", DiagnosticFailed, "%v", err)
+		return
+	}
+
+	_ = resp.Body.Close()
+
+	if resp.TLS == nil {
+		diagnosis.add("reachability", DiagnosticWarning, "the API is reached without TLS: %s", c.BaseURL.Redacted())
+	} else {
+		diagnosis.add("reachability", DiagnosticOK, "%s, %s", tls.VersionName(resp.TLS.Version), tls.CipherSuiteName(resp.TLS.CipherSuite))
+	}
+
+	date, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		diagnosis.add("clock", DiagnosticWarning, "the API doesn't provide its time")
+		return
+	}
+
+	skew := c.getClock().Now().Sub(date).Round(time.Second)
+
+	if skew > maxClockSkew || skew < -maxClockSkew {
+		diagnosis.add("clock", DiagnosticWarning, "the local clock differs from the API clock by %s", skew)
+		return
+	}
+
+	diagnosis.add("clock", DiagnosticOK, "clock skew: %s", skew)
+}
+
+// diagnoseZone reads the records of the first domain hosted at Porkbun.
+func (c *Client) diagnoseZone(ctx context.Context, diagnosis *Diagnosis) {
+	domains, err := c.ListDomains(ctx)
+	if err != nil {
+		diagnosis.add("zone-read", DiagnosticFailed, "%v", err)
+		return
+	}
+
+	for _, domain := range domains {
+		if domain.NotLocal {
+			continue
+		}
+
+		records, err := c.RetrieveRecords(ctx, domain.Domain)
+		if err != nil {
+			diagnosis.add("zone-read", DiagnosticFailed, "%s: %v", domain.Domain, err)
+			return
+		}
+
+		diagnosis.add("zone-read", DiagnosticOK, "%s: %d records", domain.Domain, len(records))
+
+		return
+	}
+
+	diagnosis.add("zone-read", DiagnosticWarning, "no domain with DNS hosted at Porkbun")
+}
+
+// networkHTTPClient returns a copy of the HTTP client restricted to a network ("tcp4" or "tcp6").
+func networkHTTPClient(client *http.Client, network string) (*http.Client, bool) {
+	transport := httpTransport(client)
+	if transport == nil {
+		return nil, false
+	}
+
+	transport = transport.Clone()
+
+	dialer := &net.Dialer{Timeout: 30 * time.Second}
+
+	transport.DialContext = func(ctx context.Context, _, addr string) (net.Conn, error) {
+		return dialer.DialContext(ctx, network, addr)
+	}
+
+	clone := *client
+	clone.Transport = transport
+
+	return &clone, true
+}
+
+// httpTransport returns the transport of the HTTP client, nil if it's a custom transport.
+func httpTransport(client *http.Client) *http.Transport {
+	switch t := client.Transport.(type) {
+	case nil:
+		transport, _ := http.DefaultTransport.(*http.Transport)
+		return transport
+	case *http.Transport:
+		return t
+	default:
+		return nil
+	}
+}
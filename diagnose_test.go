@@ -0,0 +1,107 @@
+package porkbun
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupDiagnose(t *testing.T, pingFixture string) *Client {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewTLSServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("/", func(rw http.ResponseWriter, _ *http.Request) {
+		rw.Header().Set("Date", time.Date(2024, 1, 1, 0, 0, 30, 0, time.UTC).Format(http.TimeFormat))
+	})
+	mux.HandleFunc("/ping", fixtureHandler(pingFixture))
+	mux.HandleFunc("/domain/listAll", fixtureHandler("list-all"))
+	mux.HandleFunc("/dns/retrieve/borseth.ink", fixtureHandler("retrieve"))
+
+	client := New("secret", "key", WithClock(newFakeClock()))
+	client.BaseURL, _ = url.Parse(server.URL)
+	client.HTTPClient = server.Client()
+
+	return client
+}
+
+func TestClient_Diagnose(t *testing.T) {
+	client := setupDiagnose(t, "ping")
+
+	diagnosis := client.Diagnose(context.Background())
+
+	require.True(t, diagnosis.OK(), diagnosis.String())
+
+	statuses := map[string]DiagnosticStatus{}
+	for _, check := range diagnosis.Checks {
+		statuses[check.Name] = check.Status
+	}
+
+	expected := map[string]DiagnosticStatus{
+		"proxy":        DiagnosticOK,
+		"reachability": DiagnosticOK,
+		"clock":        DiagnosticOK,
+		"ping-ipv4":    DiagnosticOK,
+		// the test server only listens on IPv4.
+		"ping-ipv6":   DiagnosticWarning,
+		"credentials": DiagnosticOK,
+		"zone-read":   DiagnosticOK,
+	}
+
+	assert.Equal(t, expected, statuses)
+	assert.Contains(t, diagnosis.String(), "[ok] zone-read: borseth.ink: 2 records")
+}
+
+func TestClient_Diagnose_credentials(t *testing.T) {
+	client := setupDiagnose(t, "error")
+
+	diagnosis := client.Diagnose(context.Background())
+
+	require.False(t, diagnosis.OK())
+
+	last := diagnosis.Checks[len(diagnosis.Checks)-1]
+	assert.Equal(t, DiagnosticCheck{Name: "credentials", Status: DiagnosticFailed, Message: "ERROR: Invalid API key. (001)"}, last)
+}
+
+type wrappingTransport struct {
+	next http.RoundTripper
+}
+
+func (w wrappingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return w.next.RoundTrip(req)
+}
+
+func TestClient_Diagnose_customTransport(t *testing.T) {
+	client := setupDiagnose(t, "ping")
+	client.HTTPClient.Transport = wrappingTransport{next: client.HTTPClient.Transport}
+
+	diagnosis := client.Diagnose(context.Background())
+
+	require.True(t, diagnosis.OK(), diagnosis.String())
+
+	statuses := map[string]DiagnosticStatus{}
+	for _, check := range diagnosis.Checks {
+		statuses[check.Name] = check.Status
+	}
+
+	expected := map[string]DiagnosticStatus{
+		"proxy":        DiagnosticOK,
+		"reachability": DiagnosticOK,
+		"clock":        DiagnosticOK,
+		"ping-ipv4":    DiagnosticWarning,
+		"ping-ipv6":    DiagnosticWarning,
+		"ping":         DiagnosticOK,
+		"credentials":  DiagnosticOK,
+		"zone-read":    DiagnosticOK,
+	}
+
+	assert.Equal(t, expected, statuses)
+}
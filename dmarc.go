@@ -0,0 +1,161 @@
+package porkbun
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+)
+
+const dmarcLabel = "_dmarc"
+
+// ErrDMARCRecordNotFound is returned when a domain has no DMARC record.
+var ErrDMARCRecordNotFound = errors.New("porkbun: DMARC record not found")
+
+// SetDMARCReportAddresses replaces the aggregate (rua) and failure (ruf) report destinations of the `_dmarc` TXT record.
+// The other tags of the record are kept, an empty list removes the tag.
+// The addresses can be written with or without the `mailto:` scheme.
+//
+// It returns the domains of the external destinations:
+// those domains must publish an authorization record (see AuthorizeDMARCReports) to receive the reports.
+func (c *Client) SetDMARCReportAddresses(ctx context.Context, domain string, rua, ruf []string) ([]string, error) {
+	if c.readOnly {
+		return nil, ErrReadOnly
+	}
+
+	records, err := c.RetrieveRecords(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	current, ok := findDMARCRecord(records, domain)
+	if !ok {
+		return nil, ErrDMARCRecordNotFound
+	}
+
+	content := setDMARCTag(current.Content, "rua", rua)
+	content = setDMARCTag(content, "ruf", ruf)
+
+	record := Record{
+		Name:    dmarcLabel,
+		Type:    current.Type,
+		Content: content,
+		TTL:     current.TTL,
+		Prio:    current.Prio,
+		Notes:   current.Notes,
+	}
+
+	err = c.EditRecord(ctx, domain, current.ID, record)
+	if err != nil {
+		return nil, err
+	}
+
+	return externalDMARCDomains(domain, append(slices.Clone(rua), ruf...)), nil
+}
+
+// AuthorizeDMARCReports creates the record authorizing the zone to receive the DMARC reports of domain
+// (`<domain>._report._dmarc.<zone>` TXT "v=DMARC1"), if it doesn't exist.
+func (c *Client) AuthorizeDMARCReports(ctx context.Context, zone, domain string) error {
+	if c.readOnly {
+		return ErrReadOnly
+	}
+
+	name := domain + "._report." + dmarcLabel
+
+	records, err := c.RetrieveRecords(ctx, zone)
+	if err != nil {
+		return err
+	}
+
+	for _, record := range records {
+		if record.Type == "TXT" && subDomain(record.Name, zone) == name && strings.HasPrefix(record.Content, "v=DMARC1") {
+			return nil
+		}
+	}
+
+	_, err = c.CreateRecord(ctx, zone, Record{
+		Name:    name,
+		Type:    "TXT",
+		Content: "v=DMARC1",
+		TTL:     DefaultTTL,
+	})
+
+	return err
+}
+
+func findDMARCRecord(records []Record, domain string) (Record, bool) {
+	for _, record := range records {
+		if record.Type == "TXT" && subDomain(record.Name, domain) == dmarcLabel && strings.HasPrefix(record.Content, "v=DMARC1") {
+			return record, true
+		}
+	}
+
+	return Record{}, false
+}
+
+// setDMARCTag replaces the value of a tag of a DMARC record, an empty list of addresses removes the tag.
+func setDMARCTag(content, tag string, addresses []string) string {
+	var value string
+
+	if len(addresses) > 0 {
+		uris := make([]string, 0, len(addresses))
+		for _, address := range addresses {
+			uris = append(uris, "mailto:"+strings.TrimPrefix(address, "mailto:"))
+		}
+
+		value = tag + "=" + strings.Join(uris, ",")
+	}
+
+	var tags []string
+
+	found := false
+
+	for _, part := range strings.Split(content, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		name, _, _ := strings.Cut(part, "=")
+		if strings.TrimSpace(name) != tag {
+			tags = append(tags, part)
+			continue
+		}
+
+		found = true
+
+		if value != "" {
+			tags = append(tags, value)
+		}
+	}
+
+	if !found && value != "" {
+		tags = append(tags, value)
+	}
+
+	return strings.Join(tags, "; ")
+}
+
+// externalDMARCDomains returns the domains of the addresses outside the domain.
+func externalDMARCDomains(domain string, addresses []string) []string {
+	var domains []string
+
+	for _, address := range addresses {
+		address, _, _ = strings.Cut(strings.TrimPrefix(address, "mailto:"), "!")
+
+		_, host, ok := strings.Cut(address, "@")
+		if !ok {
+			continue
+		}
+
+		host = strings.ToLower(strings.TrimSuffix(host, "."))
+
+		if host == domain || strings.HasSuffix(host, "."+domain) || slices.Contains(domains, host) {
+			continue
+		}
+
+		domains = append(domains, host)
+	}
+
+	return domains
+}
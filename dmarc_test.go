@@ -0,0 +1,129 @@
+package porkbun
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_SetDMARCReportAddresses(t *testing.T) {
+	client, mux := setupMux(t)
+
+	mux.HandleFunc("/dns/retrieve/example.com", fixtureHandler("retrieve-dmarc"))
+
+	var edited Record
+
+	mux.HandleFunc("/dns/edit/example.com/106926653", func(rw http.ResponseWriter, req *http.Request) {
+		err := json.NewDecoder(req.Body).Decode(&edited)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		fixtureHandler("edit")(rw, req)
+	})
+
+	rua := []string{"dmarc@example.com", "mailto:reports@example.net!10m"}
+
+	external, err := client.SetDMARCReportAddresses(context.Background(), "example.com", rua, []string{"forensic@example.org"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"example.net", "example.org"}, external)
+
+	expected := Record{
+		Name:    "_dmarc",
+		Type:    "TXT",
+		Content: "v=DMARC1; p=reject; rua=mailto:dmarc@example.com,mailto:reports@example.net!10m; pct=100; ruf=mailto:forensic@example.org",
+		TTL:     "600",
+		Prio:    "0",
+		Notes:   "dmarc",
+	}
+
+	assert.Equal(t, expected, edited)
+}
+
+func TestClient_SetDMARCReportAddresses_notFound(t *testing.T) {
+	client := setup(t, "/dns/retrieve/example.com", "retrieve")
+
+	_, err := client.SetDMARCReportAddresses(context.Background(), "example.com", []string{"dmarc@example.com"}, nil)
+	require.ErrorIs(t, err, ErrDMARCRecordNotFound)
+}
+
+func TestClient_AuthorizeDMARCReports(t *testing.T) {
+	client, mux := setupMux(t)
+
+	mux.HandleFunc("/dns/retrieve/example.net", fixtureHandler("retrieve"))
+
+	var created Record
+
+	mux.HandleFunc("/dns/create/example.net", func(rw http.ResponseWriter, req *http.Request) {
+		err := json.NewDecoder(req.Body).Decode(&created)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		fixtureHandler("create")(rw, req)
+	})
+
+	err := client.AuthorizeDMARCReports(context.Background(), "example.net", "example.com")
+	require.NoError(t, err)
+
+	expected := Record{
+		Name:    "example.com._report._dmarc",
+		Type:    "TXT",
+		Content: "v=DMARC1",
+		TTL:     DefaultTTL,
+	}
+
+	assert.Equal(t, expected, created)
+}
+
+func Test_setDMARCTag(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		content   string
+		tag       string
+		addresses []string
+		expected  string
+	}{
+		{
+			desc:      "replace",
+			content:   "v=DMARC1; p=none; rua=mailto:a@example.com",
+			tag:       "rua",
+			addresses: []string{"b@example.com"},
+			expected:  "v=DMARC1; p=none; rua=mailto:b@example.com",
+		},
+		{
+			desc:      "add",
+			content:   "v=DMARC1;p=none",
+			tag:       "ruf",
+			addresses: []string{"mailto:b@example.com"},
+			expected:  "v=DMARC1; p=none; ruf=mailto:b@example.com",
+		},
+		{
+			desc:     "remove",
+			content:  "v=DMARC1; p=none; ruf=mailto:a@example.com; fo=1",
+			tag:      "ruf",
+			expected: "v=DMARC1; p=none; fo=1",
+		},
+		{
+			desc:     "remove missing",
+			content:  "v=DMARC1; p=none;",
+			tag:      "rua",
+			expected: "v=DMARC1; p=none",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			content := setDMARCTag(test.content, test.tag, test.addresses)
+
+			assert.Equal(t, test.expected, content)
+		})
+	}
+}
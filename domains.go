@@ -0,0 +1,186 @@
+package porkbun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// listAllPageSize the number of domains returned by a call to the listAll endpoint.
+const listAllPageSize = 1000
+
+// DomainNotLocalError is returned by the DNS record methods for a domain whose DNS is not hosted at Porkbun.
+type DomainNotLocalError struct {
+	Domain string
+}
+
+func (e DomainNotLocalError) Error() string {
+	return fmt.Sprintf("porkbun: the DNS of %s is not hosted at Porkbun", e.Domain)
+}
+
+// Flag a boolean returned by the API as a number or a string ("1", "0", "yes", "no").
+type Flag bool
+
+// UnmarshalJSON decodes the flag from a JSON boolean, number, or string.
+func (f *Flag) UnmarshalJSON(data []byte) error {
+	var raw interface{}
+
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+
+	switch value := raw.(type) {
+	case nil:
+		*f = false
+	case bool:
+		*f = Flag(value)
+	case float64:
+		*f = value != 0
+	case string:
+		switch value {
+		case "", "0", "no", "false":
+			*f = false
+		default:
+			*f = true
+		}
+	default:
+		return fmt.Errorf("invalid flag: %s", data)
+	}
+
+	return nil
+}
+
+// Domain a domain of the account.
+type Domain struct {
+	Domain       string `json:"domain"`
+	Status       string `json:"status"`
+	TLD          string `json:"tld"`
+	CreateDate   string `json:"createDate"`
+	ExpireDate   string `json:"expireDate"`
+	SecurityLock Flag   `json:"securityLock"`
+	WhoisPrivacy Flag   `json:"whoisPrivacy"`
+	AutoRenew    Flag   `json:"autoRenew"`
+	// NotLocal is true when the DNS of the domain is not hosted at Porkbun.
+	NotLocal Flag `json:"notLocal"`
+}
+
+type listAllRequest struct {
+	Start string `json:"start"`
+}
+
+type listAllResponse struct {
+	Status
+	Domains []Domain `json:"domains"`
+}
+
+// ListDomains lists all the domains of the account.
+// The client remembers the domains whose DNS is not hosted at Porkbun:
+// the DNS record methods called for those domains return a DomainNotLocalError without calling the API.
+func (c *Client) ListDomains(ctx context.Context) ([]Domain, error) {
+	endpoint := c.endpoint("domain/listAll")
+
+	var domains []Domain
+
+	for {
+		respBody, err := c.do(ctx, endpoint, listAllRequest{Start: strconv.Itoa(len(domains))})
+		if err != nil {
+			return nil, err
+		}
+
+		listResp := listAllResponse{}
+		err = json.Unmarshal(respBody, &listResp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+
+		if listResp.Status.Status != statusSuccess {
+			return nil, listResp.Status
+		}
+
+		domains = append(domains, listResp.Domains...)
+
+		if len(listResp.Domains) < listAllPageSize {
+			break
+		}
+	}
+
+	c.notLocalDomains.update(domains)
+
+	return domains, nil
+}
+
+// checkLocal returns a DomainNotLocalError if the domain is known to be not hosted at Porkbun.
+func (c *Client) checkLocal(domain string) error {
+	if c.notLocalDomains.contains(domain) {
+		return &DomainNotLocalError{Domain: domain}
+	}
+
+	return nil
+}
+
+type domainSet struct {
+	mu      sync.RWMutex
+	domains map[string]struct{}
+}
+
+func (s *domainSet) update(domains []Domain) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.domains = map[string]struct{}{}
+
+	for _, domain := range domains {
+		if domain.NotLocal {
+			s.domains[domain.Domain] = struct{}{}
+		}
+	}
+}
+
+func (s *domainSet) contains(domain string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.domains[domain]
+
+	return ok
+}
+
+// Availability the availability of a domain.
+type Availability struct {
+	Available    Flag   `json:"avail"`
+	Type         string `json:"type"`
+	Price        string `json:"price"`
+	RegularPrice string `json:"regularPrice"`
+	Premium      Flag   `json:"premium"`
+}
+
+type checkDomainResponse struct {
+	Status
+	Response Availability `json:"response"`
+}
+
+// CheckDomain checks the availability of a domain and its price.
+// This endpoint is heavily rate limited by the API.
+func (c *Client) CheckDomain(ctx context.Context, domain string) (Availability, error) {
+	endpoint := c.endpoint("domain/checkDomain", domain)
+
+	respBody, err := c.do(ctx, endpoint, nil)
+	if err != nil {
+		return Availability{}, err
+	}
+
+	checkResp := checkDomainResponse{}
+	err = json.Unmarshal(respBody, &checkResp)
+	if err != nil {
+		return Availability{}, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	if checkResp.Status.Status != statusSuccess {
+		return Availability{}, checkResp.Status
+	}
+
+	return checkResp.Response, nil
+}
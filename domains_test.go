@@ -0,0 +1,120 @@
+package porkbun
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ListDomains(t *testing.T) {
+	client := setup(t, "/domain/listAll", "list-all")
+
+	domains, err := client.ListDomains(context.Background())
+	require.NoError(t, err)
+
+	expected := []Domain{
+		{
+			Domain:       "borseth.ink",
+			Status:       "ACTIVE",
+			TLD:          "ink",
+			CreateDate:   "2018-08-20 17:52:51",
+			ExpireDate:   "2023-08-20 17:52:51",
+			SecurityLock: true,
+			WhoisPrivacy: true,
+		},
+		{
+			Domain:       "example.com",
+			Status:       "ACTIVE",
+			TLD:          "com",
+			CreateDate:   "2019-01-01 00:00:00",
+			ExpireDate:   "2025-01-01 00:00:00",
+			SecurityLock: true,
+			AutoRenew:    true,
+			NotLocal:     true,
+		},
+	}
+
+	assert.Equal(t, expected, domains)
+}
+
+func TestClient_ListDomains_error(t *testing.T) {
+	client := setup(t, "/domain/listAll", "error")
+
+	_, err := client.ListDomains(context.Background())
+	require.Error(t, err)
+}
+
+func TestClient_ListDomains_notLocal(t *testing.T) {
+	client := setup(t, "/domain/listAll", "list-all")
+
+	ctx := context.Background()
+
+	_, err := client.ListDomains(ctx)
+	require.NoError(t, err)
+
+	notLocalErr := &DomainNotLocalError{}
+
+	_, err = client.RetrieveRecords(ctx, "example.com")
+	require.ErrorAs(t, err, &notLocalErr)
+	assert.Equal(t, "example.com", notLocalErr.Domain)
+
+	_, err = client.CreateRecord(ctx, "example.com", Record{Type: "A", Content: "1.1.1.1"})
+	require.ErrorAs(t, err, &notLocalErr)
+
+	err = client.DeleteRecord(ctx, "example.com", 666)
+	require.ErrorAs(t, err, &notLocalErr)
+}
+
+func TestClient_CheckDomain(t *testing.T) {
+	client := setup(t, "/domain/checkDomain/example.com", "check-domain")
+
+	availability, err := client.CheckDomain(context.Background(), "example.com")
+	require.NoError(t, err)
+
+	expected := Availability{
+		Available:    true,
+		Type:         "registration",
+		Price:        "9.68",
+		RegularPrice: "9.68",
+	}
+
+	assert.Equal(t, expected, availability)
+}
+
+func TestClient_CheckDomain_error(t *testing.T) {
+	client := setup(t, "/domain/checkDomain/example.com", "error")
+
+	_, err := client.CheckDomain(context.Background(), "example.com")
+	require.Error(t, err)
+}
+
+func TestFlag_UnmarshalJSON(t *testing.T) {
+	testCases := []struct {
+		data     string
+		expected Flag
+	}{
+		{data: `1`, expected: true},
+		{data: `0`, expected: false},
+		{data: `"1"`, expected: true},
+		{data: `"0"`, expected: false},
+		{data: `"yes"`, expected: true},
+		{data: `"no"`, expected: false},
+		{data: `""`, expected: false},
+		{data: `true`, expected: true},
+		{data: `null`, expected: false},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.data, func(t *testing.T) {
+			var flag Flag
+
+			err := json.Unmarshal([]byte(test.data), &flag)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, flag)
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package porkbun
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const generateTag = "porkbun"
+
+// GenerateRecords generates the desired DNS records described by an annotated struct.
+// The fields are annotated with the `porkbun` tag:
+//
+//	name: the subdomain of the service (string), empty for the root domain.
+//	ttl: the TTL of the records (string), DefaultTTL if empty.
+//	A, AAAA, CNAME, ALIAS, TXT, NS, CAA: records of this type with the field value(s) as content (string or []string).
+//	MX: MX records, a value can include the priority (ex: "10 mail.example.com").
+//	alias: CNAME records named by the field value(s), pointing to the service (string or []string).
+//	spf: a TXT record with the SPF policy (ex: "mx -all" or "v=spf1 mx -all").
+//
+// The struct fields (or slices of structs) without tag are generated recursively,
+// the empty values are ignored.
+func GenerateRecords(domain string, v interface{}) ([]Record, error) {
+	value := reflect.ValueOf(v)
+	if !value.IsValid() {
+		return nil, errors.New("nil value")
+	}
+
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return nil, errors.New("nil value")
+		}
+
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("unsupported type %s: a struct is expected", value.Type())
+	}
+
+	return generateRecords(domain, value)
+}
+
+func generateRecords(domain string, value reflect.Value) ([]Record, error) {
+	name, ttl := "", DefaultTTL
+
+	type taggedField struct {
+		tag    string
+		values []string
+	}
+
+	var fields []taggedField
+
+	var records []Record
+
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag, ok := field.Tag.Lookup(generateTag)
+		if !ok {
+			nested, err := generateNested(domain, value.Field(i))
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", field.Name, err)
+			}
+
+			records = append(records, nested...)
+
+			continue
+		}
+
+		values, err := stringValues(value.Field(i))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", field.Name, err)
+		}
+
+		switch tag {
+		case "name":
+			if len(values) > 0 {
+				name = values[0]
+			}
+		case "ttl":
+			if len(values) > 0 {
+				ttl = values[0]
+			}
+		default:
+			fields = append(fields, taggedField{tag: tag, values: values})
+		}
+	}
+
+	var generated []Record
+
+	for _, field := range fields {
+		for _, v := range field.values {
+			record, err := generateRecord(domain, name, field.tag, v)
+			if err != nil {
+				return nil, err
+			}
+
+			record.TTL = ttl
+
+			generated = append(generated, record)
+		}
+	}
+
+	return append(generated, records...), nil
+}
+
+func generateRecord(domain, name, tag, value string) (Record, error) {
+	switch tag {
+	case "A", "AAAA", "CNAME", "ALIAS", "TXT", "NS", "CAA":
+		return Record{Name: name, Type: tag, Content: value}, nil
+
+	case "MX":
+		record := Record{Name: name, Type: "MX", Content: value}
+
+		if prio, host, ok := strings.Cut(value, " "); ok {
+			record.Prio = prio
+			record.Content = strings.TrimSpace(host)
+		}
+
+		return record, nil
+
+	case "alias":
+		target := domain
+		if name != "" {
+			target = name + "." + domain
+		}
+
+		return Record{Name: value, Type: "CNAME", Content: target}, nil
+
+	case "spf":
+		return Record{Name: name, Type: "TXT", Content: "v=spf1 " + strings.TrimPrefix(value, "v=spf1 ")}, nil
+
+	default:
+		return Record{}, fmt.Errorf("unsupported tag %q", tag)
+	}
+}
+
+func generateNested(domain string, value reflect.Value) ([]Record, error) {
+	switch value.Kind() {
+	case reflect.Pointer:
+		if value.IsNil() {
+			return nil, nil
+		}
+
+		return generateNested(domain, value.Elem())
+
+	case reflect.Struct:
+		return generateRecords(domain, value)
+
+	case reflect.Slice:
+		var records []Record
+
+		for i := 0; i < value.Len(); i++ {
+			nested, err := generateNested(domain, value.Index(i))
+			if err != nil {
+				return nil, err
+			}
+
+			records = append(records, nested...)
+		}
+
+		return records, nil
+
+	default:
+		return nil, nil
+	}
+}
+
+func stringValues(value reflect.Value) ([]string, error) {
+	switch {
+	case value.Kind() == reflect.String:
+		if value.String() == "" {
+			return nil, nil
+		}
+
+		return []string{value.String()}, nil
+
+	case value.Kind() == reflect.Slice && value.Type().Elem().Kind() == reflect.String:
+		var values []string
+
+		for i := 0; i < value.Len(); i++ {
+			if s := value.Index(i).String(); s != "" {
+				values = append(values, s)
+			}
+		}
+
+		return values, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported type %s: string or []string expected", value.Type())
+	}
+}
@@ -0,0 +1,102 @@
+package porkbun
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testMail struct {
+	MX  []string `porkbun:"MX"`
+	SPF string   `porkbun:"spf"`
+}
+
+type testService struct {
+	Name    string   `porkbun:"name"`
+	TTL     string   `porkbun:"ttl"`
+	IPv4    string   `porkbun:"A"`
+	IPv6    string   `porkbun:"AAAA"`
+	Aliases []string `porkbun:"alias"`
+}
+
+type testInfra struct {
+	Mail     testMail
+	Services []testService
+	Comment  string
+}
+
+func TestGenerateRecords(t *testing.T) {
+	infra := testInfra{
+		Mail: testMail{
+			MX:  []string{"10 mx1.example.com", "mx2.example.com"},
+			SPF: "mx -all",
+		},
+		Services: []testService{
+			{IPv4: "203.0.113.5"},
+			{
+				Name:    "api",
+				TTL:     "600",
+				IPv4:    "203.0.113.6",
+				IPv6:    "2001:db8::6",
+				Aliases: []string{"rest", "graphql"},
+			},
+		},
+		Comment: "ignored",
+	}
+
+	records, err := GenerateRecords("example.com", &infra)
+	require.NoError(t, err)
+
+	expected := []Record{
+		{Type: "MX", Content: "mx1.example.com", TTL: DefaultTTL, Prio: "10"},
+		{Type: "MX", Content: "mx2.example.com", TTL: DefaultTTL},
+		{Type: "TXT", Content: "v=spf1 mx -all", TTL: DefaultTTL},
+		{Type: "A", Content: "203.0.113.5", TTL: DefaultTTL},
+		{Name: "api", Type: "A", Content: "203.0.113.6", TTL: "600"},
+		{Name: "api", Type: "AAAA", Content: "2001:db8::6", TTL: "600"},
+		{Name: "rest", Type: "CNAME", Content: "api.example.com", TTL: "600"},
+		{Name: "graphql", Type: "CNAME", Content: "api.example.com", TTL: "600"},
+	}
+
+	assert.Equal(t, expected, records)
+}
+
+func TestGenerateRecords_errors(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		value interface{}
+	}{
+		{
+			desc:  "not a struct",
+			value: "example",
+		},
+		{
+			desc:  "nil",
+			value: nil,
+		},
+		{
+			desc:  "nil pointer",
+			value: (*testService)(nil),
+		},
+		{
+			desc: "unsupported tag",
+			value: struct {
+				IP string `porkbun:"PTR"`
+			}{IP: "203.0.113.5"},
+		},
+		{
+			desc: "unsupported type",
+			value: struct {
+				Port int `porkbun:"A"`
+			}{Port: 80},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			_, err := GenerateRecords("example.com", test.value)
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package porkbun
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// LintRule a rule of the record linter.
+type LintRule string
+
+// Lint rules.
+const (
+	LintCNAMEAtApex  LintRule = "cname-at-apex"
+	LintTrailingDot  LintRule = "trailing-dot"
+	LintIPInCNAME    LintRule = "ip-in-cname"
+	LintSPFNotInTXT  LintRule = "spf-not-in-txt"
+	LintMXPointsAtIP LintRule = "mx-points-at-ip"
+)
+
+// LintWarning a likely mistake in a DNS record.
+// Unlike an API error, a warning doesn't prevent the record from being created.
+type LintWarning struct {
+	Rule    LintRule
+	Message string
+}
+
+func (w LintWarning) String() string {
+	return fmt.Sprintf("%s: %s", w.Rule, w.Message)
+}
+
+// LintHandler handles the warnings of a record about to be created or edited (see WithLintHandler).
+// A non-nil error aborts the change and is returned by the method.
+type LintHandler func(domain string, record Record, warnings []LintWarning) error
+
+// LintRecord checks a record for common mistakes.
+// The record name is the subdomain, as for CreateRecord.
+func LintRecord(domain string, record Record) []LintWarning {
+	recordType := strings.ToUpper(record.Type)
+	content := strings.TrimSpace(record.Content)
+
+	var warnings []LintWarning
+
+	if recordType == "CNAME" && (record.Name == "" || record.Name == domain) {
+		warnings = append(warnings, LintWarning{
+			Rule:    LintCNAMEAtApex,
+			Message: "a CNAME record at the apex conflicts with the SOA and NS records, use an ALIAS record",
+		})
+	}
+
+	switch recordType {
+	case "CNAME", "ALIAS", "MX", "NS", "SRV":
+		if strings.HasSuffix(content, ".") {
+			warnings = append(warnings, LintWarning{
+				Rule:    LintTrailingDot,
+				Message: fmt.Sprintf("the content %q ends with a dot, Porkbun expects the name without the trailing dot", content),
+			})
+		}
+	}
+
+	_, ipErr := netip.ParseAddr(content)
+
+	switch {
+	case (recordType == "CNAME" || recordType == "ALIAS") && ipErr == nil:
+		warnings = append(warnings, LintWarning{
+			Rule:    LintIPInCNAME,
+			Message: fmt.Sprintf("a %s record must point to a name, not an IP address (%s), use an A or AAAA record", recordType, content),
+		})
+
+	case recordType == "MX" && ipErr == nil:
+		warnings = append(warnings, LintWarning{
+			Rule:    LintMXPointsAtIP,
+			Message: fmt.Sprintf("a MX record must point to a name, not an IP address (%s)", content),
+		})
+	}
+
+	if recordType != "TXT" && strings.HasPrefix(strings.ToLower(strings.Trim(content, `"`)), "v=spf1") {
+		warnings = append(warnings, LintWarning{
+			Rule:    LintSPFNotInTXT,
+			Message: fmt.Sprintf("SPF policies must be published in a TXT record, not in a %s record", recordType),
+		})
+	}
+
+	return warnings
+}
+
+// lint runs the lint handler on the record, if any.
+func (c *Client) lint(domain string, record Record) error {
+	if c.lintHandler == nil {
+		return nil
+	}
+
+	warnings := LintRecord(domain, record)
+	if len(warnings) == 0 {
+		return nil
+	}
+
+	return c.lintHandler(domain, record, warnings)
+}
@@ -0,0 +1,110 @@
+package porkbun
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintRecord(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		record   Record
+		expected []LintRule
+	}{
+		{
+			desc:   "valid A",
+			record: Record{Name: "www", Type: "A", Content: "1.1.1.1"},
+		},
+		{
+			desc:   "valid CNAME",
+			record: Record{Name: "www", Type: "CNAME", Content: "example.net"},
+		},
+		{
+			desc:   "valid SPF",
+			record: Record{Type: "TXT", Content: "v=spf1 mx -all"},
+		},
+		{
+			desc:     "CNAME at apex",
+			record:   Record{Type: "CNAME", Content: "example.net"},
+			expected: []LintRule{LintCNAMEAtApex},
+		},
+		{
+			desc:     "trailing dot",
+			record:   Record{Name: "www", Type: "CNAME", Content: "example.net."},
+			expected: []LintRule{LintTrailingDot},
+		},
+		{
+			desc:     "IP in CNAME",
+			record:   Record{Name: "www", Type: "cname", Content: "1.1.1.1"},
+			expected: []LintRule{LintIPInCNAME},
+		},
+		{
+			desc:     "IPv6 in ALIAS",
+			record:   Record{Type: "ALIAS", Content: "2001:db8::1"},
+			expected: []LintRule{LintIPInCNAME},
+		},
+		{
+			desc:     "SPF in SPF record",
+			record:   Record{Type: "SPF", Content: `"v=spf1 mx -all"`},
+			expected: []LintRule{LintSPFNotInTXT},
+		},
+		{
+			desc:     "MX points at IP",
+			record:   Record{Type: "MX", Content: "1.1.1.1", Prio: "10"},
+			expected: []LintRule{LintMXPointsAtIP},
+		},
+		{
+			desc:     "MX trailing dot",
+			record:   Record{Type: "MX", Content: "mail.example.com."},
+			expected: []LintRule{LintTrailingDot},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			var rules []LintRule
+			for _, warning := range LintRecord("example.com", test.record) {
+				rules = append(rules, warning.Rule)
+			}
+
+			assert.Equal(t, test.expected, rules)
+		})
+	}
+}
+
+func TestWithLintHandler(t *testing.T) {
+	client := setup(t, "/dns/create/example.com", "create")
+
+	var warnings []LintWarning
+
+	WithLintHandler(func(_ string, _ Record, w []LintWarning) error {
+		warnings = w
+		return nil
+	})(client)
+
+	_, err := client.CreateRecord(context.Background(), "example.com", Record{Type: "CNAME", Content: "example.net"})
+	require.NoError(t, err)
+
+	require.Len(t, warnings, 1)
+	assert.Equal(t, LintCNAMEAtApex, warnings[0].Rule)
+}
+
+func TestWithLintHandler_abort(t *testing.T) {
+	client := setup(t, "/dns/edit/example.com/666", "edit")
+
+	errLint := errors.New("lint")
+
+	WithLintHandler(func(_ string, _ Record, _ []LintWarning) error {
+		return errLint
+	})(client)
+
+	err := client.EditRecord(context.Background(), "example.com", 666, Record{Type: "MX", Content: "1.1.1.1"})
+	require.ErrorIs(t, err, errLint)
+
+	err = client.EditRecord(context.Background(), "example.com", 666, Record{Type: "MX", Content: "mail.example.com"})
+	require.NoError(t, err)
+}
@@ -0,0 +1,54 @@
+package porkbun
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+)
+
+const porkbunNameserverSuffix = ".porkbun.com"
+
+// Resolver looks up the public DNS records (ex: net.DefaultResolver).
+type Resolver interface {
+	LookupNS(ctx context.Context, name string) ([]*net.NS, error)
+}
+
+// DNSHosting the DNS hosting of a domain, based on its public NS records.
+type DNSHosting struct {
+	// Nameservers the nameservers of the domain, without the trailing dot.
+	Nameservers []string
+	// ServedByPorkbun is true when all the nameservers are Porkbun nameservers.
+	ServedByPorkbun bool
+}
+
+// VerifyServedByPorkbun checks whether the domain resolves via the Porkbun nameservers.
+// When the DNS of the domain is hosted elsewhere, the changes made through the API have no effect.
+func (c *Client) VerifyServedByPorkbun(ctx context.Context, domain string) (DNSHosting, error) {
+	records, err := c.getResolver().LookupNS(ctx, domain)
+	if err != nil {
+		return DNSHosting{}, fmt.Errorf("failed to lookup NS records of %s: %w", domain, err)
+	}
+
+	hosting := DNSHosting{ServedByPorkbun: len(records) > 0}
+
+	for _, record := range records {
+		host := strings.ToLower(strings.TrimSuffix(record.Host, "."))
+
+		hosting.Nameservers = append(hosting.Nameservers, host)
+
+		if !strings.HasSuffix(host, porkbunNameserverSuffix) {
+			hosting.ServedByPorkbun = false
+		}
+	}
+
+	return hosting, nil
+}
+
+func (c *Client) getResolver() Resolver {
+	if c.resolver == nil {
+		return net.DefaultResolver
+	}
+
+	return c.resolver
+}
@@ -0,0 +1,83 @@
+package porkbun
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeResolver a resolver answering the NS lookups with a function.
+type fakeResolver func(ctx context.Context, name string) ([]*net.NS, error)
+
+func (f fakeResolver) LookupNS(ctx context.Context, name string) ([]*net.NS, error) {
+	return f(ctx, name)
+}
+
+func fakeLookupNS(hosts ...string) fakeResolver {
+	return func(_ context.Context, _ string) ([]*net.NS, error) {
+		var records []*net.NS
+		for _, host := range hosts {
+			records = append(records, &net.NS{Host: host})
+		}
+
+		return records, nil
+	}
+}
+
+func TestClient_VerifyServedByPorkbun(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		hosts    []string
+		expected DNSHosting
+	}{
+		{
+			desc:  "porkbun",
+			hosts: []string{"curitiba.ns.porkbun.com.", "Fortaleza.ns.porkbun.com."},
+			expected: DNSHosting{
+				Nameservers:     []string{"curitiba.ns.porkbun.com", "fortaleza.ns.porkbun.com"},
+				ServedByPorkbun: true,
+			},
+		},
+		{
+			desc:  "external",
+			hosts: []string{"ns1.example.net.", "ns2.example.net."},
+			expected: DNSHosting{
+				Nameservers: []string{"ns1.example.net", "ns2.example.net"},
+			},
+		},
+		{
+			desc:  "mixed",
+			hosts: []string{"curitiba.ns.porkbun.com.", "ns1.example.net."},
+			expected: DNSHosting{
+				Nameservers: []string{"curitiba.ns.porkbun.com", "ns1.example.net"},
+			},
+		},
+		{
+			desc: "no nameservers",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			client := New("secret", "key", WithResolver(fakeLookupNS(test.hosts...)))
+
+			hosting, err := client.VerifyServedByPorkbun(context.Background(), "example.com")
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, hosting)
+		})
+	}
+}
+
+func TestClient_VerifyServedByPorkbun_error(t *testing.T) {
+	client := New("secret", "key", WithResolver(fakeResolver(func(_ context.Context, _ string) ([]*net.NS, error) {
+		return nil, errors.New("no such host")
+	})))
+
+	_, err := client.VerifyServedByPorkbun(context.Background(), "example.com")
+	require.Error(t, err)
+}
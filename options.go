@@ -0,0 +1,121 @@
+package porkbun
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrReadOnly is returned by the mutating methods of a read-only client.
+var ErrReadOnly = errors.New("porkbun: read-only client")
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithReadOnly makes the client read-only:
+// the methods that change DNS records return ErrReadOnly without calling the API.
+func WithReadOnly() Option {
+	return func(c *Client) {
+		c.readOnly = true
+	}
+}
+
+// WithSafeguards requires an explicit confirmation (see WithConfirmation)
+// to create, modify, or delete wildcard records and apex NS records.
+// Without confirmation, the methods return a SafeguardError without changing the records.
+func WithSafeguards() Option {
+	return func(c *Client) {
+		c.safeguards = true
+	}
+}
+
+// WithSortedRecords sorts the records returned by RetrieveRecords by name, type, and content (see Client.SortRecords).
+func WithSortedRecords() Option {
+	return func(c *Client) {
+		c.SortRecords = true
+	}
+}
+
+// WithRecordsCacheTTL sets how long the records retrieved by RetrieveRecordsPage are cached.
+// The default is 30 seconds.
+func WithRecordsCacheTTL(ttl time.Duration) Option {
+	return func(c *Client) {
+		c.recordsCache.ttl = ttl
+	}
+}
+
+// WithLintHandler lints the records before they are created or edited (see LintRecord),
+// the handler is called with the warnings, if any.
+func WithLintHandler(handler LintHandler) Option {
+	return func(c *Client) {
+		c.lintHandler = handler
+	}
+}
+
+// WithDeleteVerification verifies that a record is gone after DeleteRecord.
+// The record is checked after the delay, while it persists, the deletion is retried, up to attempts deletions.
+// When the record still exists, DeleteRecord returns a DeletionNotVerifiedError.
+func WithDeleteVerification(attempts int, delay time.Duration) Option {
+	return func(c *Client) {
+		c.deleteVerification = deleteVerification{attempts: attempts, delay: delay}
+	}
+}
+
+// WithAPIVersion sets the version of the API, replacing the version of Client.BaseURL (DefaultAPIVersion by default).
+// When endpoints are provided (ex: "dns/create", "ping"), only those endpoints use the version,
+// to adopt a new version endpoint by endpoint.
+func WithAPIVersion(version string, endpoints ...string) Option {
+	return func(c *Client) {
+		if len(endpoints) == 0 {
+			c.apiVersion = version
+			return
+		}
+
+		if c.endpointVersions == nil {
+			c.endpointVersions = map[string]string{}
+		}
+
+		for _, endpoint := range endpoints {
+			c.endpointVersions[endpoint] = version
+		}
+	}
+}
+
+// WithResolver sets the resolver used to look up the public DNS records (net.DefaultResolver by default).
+func WithResolver(resolver Resolver) Option {
+	return func(c *Client) {
+		c.resolver = resolver
+	}
+}
+
+// WithClock sets the clock used by the client (the real clock by default).
+func WithClock(clock Clock) Option {
+	return func(c *Client) {
+		c.clock = clock
+	}
+}
+
+// WithUsageWindow sets the duration of the sliding window used to count the API calls (see Client.Usage).
+// The default is 1 hour.
+func WithUsageWindow(window time.Duration) Option {
+	return func(c *Client) {
+		c.usage.window = window
+	}
+}
+
+// WithUsageThreshold calls the handler when the number of API calls during the usage window reaches the threshold.
+func WithUsageThreshold(threshold int, handler UsageHandler) Option {
+	return func(c *Client) {
+		c.usage.threshold = threshold
+		c.usage.handler = handler
+	}
+}
+
+// WithStatusChecker consults the status checker after threshold consecutive server errors (5xx responses, see ServerError).
+// When the checker reports an ongoing incident, the API errors are wrapped in an IncidentError.
+// The result of the checker is reused for one minute, to not consult it on each failed call during an outage.
+func WithStatusChecker(threshold int, checker StatusChecker) Option {
+	return func(c *Client) {
+		c.status.threshold = threshold
+		c.status.checker = checker
+	}
+}
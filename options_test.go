@@ -0,0 +1,98 @@
+package porkbun
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithReadOnly(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		t.Errorf("unexpected API call: %s", req.URL.Path)
+		rw.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(server.Close)
+
+	client := New("secret", "key", WithReadOnly())
+	client.BaseURL, _ = url.Parse(server.URL)
+
+	ctx := context.Background()
+
+	_, err := client.CreateRecord(ctx, "example.com", Record{Type: "A", Content: "1.1.1.1"})
+	require.ErrorIs(t, err, ErrReadOnly)
+
+	err = client.EditRecord(ctx, "example.com", 666, Record{Type: "A", Content: "1.1.1.1"})
+	require.ErrorIs(t, err, ErrReadOnly)
+
+	err = client.DeleteRecord(ctx, "example.com", 666)
+	require.ErrorIs(t, err, ErrReadOnly)
+
+	content := "1.1.1.1"
+	err = client.PatchRecord(ctx, "example.com", 666, RecordPatch{Content: &content})
+	require.ErrorIs(t, err, ErrReadOnly)
+
+	_, err = client.CleanupStaleACME(ctx, "example.com", time.Hour)
+	require.ErrorIs(t, err, ErrReadOnly)
+}
+
+func TestWithReadOnly_read(t *testing.T) {
+	client := setup(t, "/dns/retrieve/example.com", "retrieve")
+	WithReadOnly()(client)
+
+	_, err := client.RetrieveRecords(context.Background(), "example.com")
+	require.NoError(t, err)
+}
+
+func TestWithSortedRecords(t *testing.T) {
+	client := setup(t, "/dns/retrieve/example.com", "retrieve-acme")
+	WithSortedRecords()(client)
+
+	records, err := client.RetrieveRecords(context.Background(), "example.com")
+	require.NoError(t, err)
+
+	require.Len(t, records, 4)
+	require.Equal(t, RecordID(106926655), records[0].ID)
+	require.Equal(t, RecordID(106926652), records[3].ID)
+}
+
+func TestWithAPIVersion(t *testing.T) {
+	client := setup(t, "/v4/ping", "ping")
+	WithAPIVersion("v4")(client)
+
+	_, err := client.Ping(context.Background())
+	require.NoError(t, err)
+}
+
+func TestWithAPIVersion_versionedBaseURL(t *testing.T) {
+	client, mux := setupMux(t)
+	client.BaseURL = client.BaseURL.JoinPath("api", "json", "v3", "/")
+	WithAPIVersion("v4", "ping")(client)
+
+	mux.HandleFunc("/api/json/v4/ping", fixtureHandler("ping"))
+	mux.HandleFunc("/api/json/v3/dns/retrieve/example.com", fixtureHandler("retrieve"))
+
+	_, err := client.Ping(context.Background())
+	require.NoError(t, err)
+
+	_, err = client.RetrieveRecords(context.Background(), "example.com")
+	require.NoError(t, err)
+}
+
+func TestWithAPIVersion_endpoints(t *testing.T) {
+	client, mux := setupMux(t)
+	WithAPIVersion("v4", "ping")(client)
+
+	mux.HandleFunc("/v4/ping", fixtureHandler("ping"))
+	mux.HandleFunc("/dns/retrieve/example.com", fixtureHandler("retrieve"))
+
+	_, err := client.Ping(context.Background())
+	require.NoError(t, err)
+
+	_, err = client.RetrieveRecords(context.Background(), "example.com")
+	require.NoError(t, err)
+}
@@ -0,0 +1,116 @@
+package porkbun
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+const defaultRecordsCacheTTL = 30 * time.Second
+
+// RecordsPage a page of DNS records.
+type RecordsPage struct {
+	// Records the records of the page.
+	Records []Record
+	// Offset the position of the first record of the page.
+	Offset int
+	// Total the number of records matching the filter.
+	Total int
+}
+
+// RetrieveRecordsPage retrieves a page of the DNS records of a domain, sorted by name, type, and content.
+// The records are always sorted, whatever Client.SortRecords is set to.
+// The records are retrieved once and cached by the client (see WithRecordsCacheTTL),
+// the pages are computed from the cached records.
+// The cache of a domain is invalidated when a record of the domain is created, edited, or deleted through the client.
+//
+//	offset: the position of the first record of the page.
+//	limit: the maximum number of records of the page, 0 for all the records.
+//	filter (optional): selects the records to include.
+func (c *Client) RetrieveRecordsPage(ctx context.Context, domain string, offset, limit int, filter func(Record) bool) (RecordsPage, error) {
+	records, err := c.cachedRecords(ctx, domain)
+	if err != nil {
+		return RecordsPage{}, err
+	}
+
+	if filter != nil {
+		records = slices.DeleteFunc(records, func(record Record) bool { return !filter(record) })
+	}
+
+	page := RecordsPage{Offset: offset, Total: len(records)}
+
+	if offset < 0 || offset >= len(records) {
+		return page, nil
+	}
+
+	end := len(records)
+	if limit > 0 && limit < end-offset {
+		end = offset + limit
+	}
+
+	page.Records = records[offset:end]
+
+	return page, nil
+}
+
+// cachedRecords returns a copy of the cached records of the domain, the records are retrieved if needed.
+func (c *Client) cachedRecords(ctx context.Context, domain string) ([]Record, error) {
+	records, ok := c.recordsCache.get(domain, c.getClock().Now())
+	if ok {
+		return records, nil
+	}
+
+	records, err := c.RetrieveRecords(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	sortRecords(records)
+
+	c.recordsCache.set(domain, records, c.getClock().Now())
+
+	return slices.Clone(records), nil
+}
+
+type recordsCacheEntry struct {
+	records   []Record
+	expiresAt time.Time
+}
+
+type recordsCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]recordsCacheEntry
+}
+
+func (r *recordsCache) get(domain string, now time.Time) ([]Record, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, ok := r.entries[domain]
+	if !ok || now.After(entry.expiresAt) {
+		return nil, false
+	}
+
+	return slices.Clone(entry.records), true
+}
+
+func (r *recordsCache) set(domain string, records []Record, now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.entries == nil {
+		r.entries = map[string]recordsCacheEntry{}
+	}
+
+	r.entries[domain] = recordsCacheEntry{records: records, expiresAt: now.Add(r.ttl)}
+}
+
+func (r *recordsCache) invalidate(domain string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.entries, domain)
+}
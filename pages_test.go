@@ -0,0 +1,86 @@
+package porkbun
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_RetrieveRecordsPage(t *testing.T) {
+	client, mux := setupMux(t)
+
+	var calls int
+
+	mux.HandleFunc("/dns/retrieve/example.com", func(rw http.ResponseWriter, req *http.Request) {
+		calls++
+
+		fixtureHandler("retrieve-acme")(rw, req)
+	})
+	mux.HandleFunc("/dns/create/example.com", fixtureHandler("create"))
+
+	ctx := context.Background()
+
+	page, err := client.RetrieveRecordsPage(ctx, "example.com", 0, 3, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, page.Total)
+	assert.Equal(t, 0, page.Offset)
+	assert.Equal(t, []RecordID{106926655, 106926653, 106926654}, recordIDs(page.Records))
+
+	page, err = client.RetrieveRecordsPage(ctx, "example.com", 3, 3, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, page.Total)
+	assert.Equal(t, []RecordID{106926652}, recordIDs(page.Records))
+
+	page, err = client.RetrieveRecordsPage(ctx, "example.com", 1, 0, func(record Record) bool { return record.Type == "TXT" })
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, page.Total)
+	assert.Equal(t, []RecordID{106926653, 106926654}, recordIDs(page.Records))
+
+	page, err = client.RetrieveRecordsPage(ctx, "example.com", 10, 3, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, page.Total)
+	assert.Empty(t, page.Records)
+
+	assert.Equal(t, 1, calls)
+
+	_, err = client.CreateRecord(ctx, "example.com", Record{Type: "A", Content: "1.1.1.1"})
+	require.NoError(t, err)
+
+	_, err = client.RetrieveRecordsPage(ctx, "example.com", 0, 3, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, calls)
+}
+
+func TestClient_RetrieveRecordsPage_largeLimit(t *testing.T) {
+	client := setup(t, "/dns/retrieve/example.com", "retrieve-acme")
+
+	page, err := client.RetrieveRecordsPage(context.Background(), "example.com", 1, math.MaxInt, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []RecordID{106926653, 106926654, 106926652}, recordIDs(page.Records))
+}
+
+func TestClient_RetrieveRecordsPage_error(t *testing.T) {
+	client := setup(t, "/dns/retrieve/example.com", "error")
+
+	_, err := client.RetrieveRecordsPage(context.Background(), "example.com", 0, 10, nil)
+	require.Error(t, err)
+}
+
+func recordIDs(records []Record) []RecordID {
+	var ids []RecordID
+	for _, record := range records {
+		ids = append(ids, record.ID)
+	}
+
+	return ids
+}
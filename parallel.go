@@ -0,0 +1,124 @@
+package porkbun
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const defaultConcurrency = 4
+
+// Operation an operation executed by ParallelOps.
+type Operation func(ctx context.Context, client *Client) (interface{}, error)
+
+// ParallelOptions the options of ParallelOps.
+type ParallelOptions struct {
+	// Concurrency the maximum number of operations running at the same time (4 by default).
+	Concurrency int
+	// Interval the minimum interval between the start of two operations,
+	// shared by all the operations to stay under the API rate limit.
+	Interval time.Duration
+	// StopOnError skips the operations not started yet after the first error.
+	StopOnError bool
+}
+
+// OperationResult the result of an operation.
+type OperationResult struct {
+	Value interface{}
+	Err   error
+}
+
+// OperationResults the results of the operations, in the order of the operations.
+type OperationResults []OperationResult
+
+// Err returns the errors of the operations, nil if all the operations succeeded.
+func (r OperationResults) Err() error {
+	var errs []error
+
+	for i, result := range r {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("operation %d: %w", i, result.Err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ParallelOps executes the operations concurrently.
+// The results keep the value and the error of each operation:
+// a failed operation doesn't prevent the others from running, unless ParallelOptions.StopOnError is set.
+// The operations not executed have the context error as error.
+func (c *Client) ParallelOps(ctx context.Context, ops []Operation, opts ParallelOptions) OperationResults {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(OperationResults, len(ops))
+
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+
+	for w := 0; w < min(concurrency, len(ops)); w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range indexes {
+				// the dispatcher can send an index after the cancellation:
+				// the operation is skipped as if it was not dispatched.
+				if ctx.Err() != nil {
+					results[i] = OperationResult{Err: ctx.Err()}
+					continue
+				}
+
+				value, err := ops[i](ctx, c)
+
+				results[i] = OperationResult{Value: value, Err: err}
+
+				if err != nil && opts.StopOnError {
+					cancel()
+				}
+			}
+		}()
+	}
+
+	next := 0
+
+dispatch:
+	for ; next < len(ops); next++ {
+		if next > 0 && opts.Interval > 0 {
+			select {
+			case <-ctx.Done():
+				break dispatch
+			case <-c.getClock().After(opts.Interval):
+			}
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			break dispatch
+		case indexes <- next:
+		}
+	}
+
+	close(indexes)
+	wg.Wait()
+
+	for i := next; i < len(ops); i++ {
+		results[i] = OperationResult{Err: ctx.Err()}
+	}
+
+	return results
+}
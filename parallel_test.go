@@ -0,0 +1,122 @@
+package porkbun
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ParallelOps(t *testing.T) {
+	client := setup(t, "/ping", "ping")
+
+	errOp := errors.New("operation error")
+
+	var running, maxRunning atomic.Int32
+
+	track := func() func() {
+		n := running.Add(1)
+		for {
+			current := maxRunning.Load()
+			if n <= current || maxRunning.CompareAndSwap(current, n) {
+				break
+			}
+		}
+
+		time.Sleep(10 * time.Millisecond)
+
+		return func() { running.Add(-1) }
+	}
+
+	ping := func(ctx context.Context, client *Client) (interface{}, error) {
+		defer track()()
+
+		return client.Ping(ctx)
+	}
+
+	failure := func(_ context.Context, _ *Client) (interface{}, error) {
+		defer track()()
+
+		return nil, errOp
+	}
+
+	results := client.ParallelOps(context.Background(), []Operation{ping, failure, ping, ping, ping}, ParallelOptions{Concurrency: 2})
+
+	require.Len(t, results, 5)
+
+	for i, result := range results {
+		if i == 1 {
+			require.ErrorIs(t, result.Err, errOp)
+			continue
+		}
+
+		require.NoError(t, result.Err)
+		assert.Equal(t, "2a02:842b:5da:c101:4b81:e1b5:83f7:3e7c", result.Value)
+	}
+
+	require.ErrorIs(t, results.Err(), errOp)
+	assert.LessOrEqual(t, maxRunning.Load(), int32(2))
+}
+
+func TestClient_ParallelOps_stopOnError(t *testing.T) {
+	client := New("secret", "key")
+
+	errOp := errors.New("operation error")
+
+	var calls atomic.Int32
+
+	op := func(_ context.Context, _ *Client) (interface{}, error) {
+		calls.Add(1)
+
+		return nil, errOp
+	}
+
+	ops := []Operation{op, op, op, op}
+
+	results := client.ParallelOps(context.Background(), ops, ParallelOptions{Concurrency: 1, Interval: 10 * time.Millisecond, StopOnError: true})
+
+	require.Len(t, results, 4)
+	require.ErrorIs(t, results[0].Err, errOp)
+	require.ErrorIs(t, results[3].Err, context.Canceled)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestClient_ParallelOps_stopOnErrorWithoutInterval(t *testing.T) {
+	client := New("secret", "key")
+
+	errOp := errors.New("operation error")
+
+	var calls atomic.Int32
+
+	op := func(_ context.Context, _ *Client) (interface{}, error) {
+		calls.Add(1)
+
+		return nil, errOp
+	}
+
+	ops := make([]Operation, 20)
+	for i := range ops {
+		ops[i] = op
+	}
+
+	results := client.ParallelOps(context.Background(), ops, ParallelOptions{Concurrency: 1, StopOnError: true})
+
+	require.Len(t, results, 20)
+	require.ErrorIs(t, results[0].Err, errOp)
+
+	for _, result := range results[1:] {
+		require.ErrorIs(t, result.Err, context.Canceled)
+	}
+
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestOperationResults_Err(t *testing.T) {
+	results := OperationResults{{Value: 1}, {Value: 2}}
+
+	require.NoError(t, results.Err())
+}
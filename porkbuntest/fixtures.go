@@ -0,0 +1,60 @@
+// Package porkbuntest contains helpers to test the code using the Porkbun API client.
+package porkbuntest
+
+import (
+	"embed"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"testing"
+)
+
+//go:embed fixtures/*.json
+var fixtures embed.FS
+
+// Fixture returns a fixture: a sanitized snapshot of a real API response (ex: "retrieve", "create-string-id").
+func Fixture(name string) ([]byte, error) {
+	return fixtures.ReadFile(path.Join("fixtures", name+".json"))
+}
+
+// LoadFixture loads a fixture, the test fails if the fixture doesn't exist.
+func LoadFixture(tb testing.TB, name string) []byte {
+	tb.Helper()
+
+	data, err := Fixture(name)
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	return data
+}
+
+// FixtureNames returns the names of all the fixtures, sorted.
+func FixtureNames() []string {
+	entries, _ := fixtures.ReadDir("fixtures")
+
+	var names []string
+
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), ".json"))
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// FixtureHandler returns a handler responding with a fixture.
+func FixtureHandler(name string) http.HandlerFunc {
+	return func(rw http.ResponseWriter, _ *http.Request) {
+		data, err := Fixture(name)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		rw.Header().Set("Content-Type", "application/json")
+		_, _ = rw.Write(data)
+	}
+}
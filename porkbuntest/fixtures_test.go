@@ -0,0 +1,38 @@
+package porkbuntest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixtureNames(t *testing.T) {
+	names := FixtureNames()
+
+	assert.Contains(t, names, "ping")
+	assert.Contains(t, names, "retrieve-quirks")
+	assert.IsIncreasing(t, names)
+
+	for _, name := range names {
+		assert.True(t, json.Valid(LoadFixture(t, name)), name)
+	}
+}
+
+func TestFixture_unknown(t *testing.T) {
+	_, err := Fixture("unknown")
+	require.Error(t, err)
+}
+
+func TestFixtureHandler(t *testing.T) {
+	recorder := httptest.NewRecorder()
+
+	FixtureHandler("ping")(recorder, httptest.NewRequest(http.MethodPost, "/ping", http.NoBody))
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	assert.JSONEq(t, string(LoadFixture(t, "ping")), recorder.Body.String())
+}
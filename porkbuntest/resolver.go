@@ -0,0 +1,141 @@
+package porkbuntest
+
+import (
+	"context"
+	"net"
+	"strings"
+)
+
+// DefaultNameservers the nameservers of the zones without apex NS records.
+var DefaultNameservers = []string{
+	"curitiba.ns.porkbun.com",
+	"fortaleza.ns.porkbun.com",
+	"maceio.ns.porkbun.com",
+	"salvador.ns.porkbun.com",
+}
+
+// Resolver a stub DNS resolver answering from the zones of a fake server, with the same methods as net.Resolver.
+// The answers reflect the current state of the zones: the changes made through the API are visible immediately.
+// Only the records of the name are returned: the CNAME records and the wildcards are not followed.
+type Resolver struct {
+	server *Server
+}
+
+// Resolver returns a stub DNS resolver answering from the zones of the server.
+func (s *Server) Resolver() *Resolver {
+	return &Resolver{server: s}
+}
+
+// LookupNS returns the NS records of the name,
+// DefaultNameservers for the apex of a zone without apex NS records.
+func (r *Resolver) LookupNS(_ context.Context, name string) ([]*net.NS, error) {
+	contents, apex := r.lookup(name, "NS")
+	if len(contents) == 0 && apex {
+		contents = DefaultNameservers
+	}
+
+	if len(contents) == 0 {
+		return nil, notFound(name)
+	}
+
+	var records []*net.NS
+	for _, content := range contents {
+		records = append(records, &net.NS{Host: fqdn(content)})
+	}
+
+	return records, nil
+}
+
+// LookupTXT returns the TXT records of the name.
+func (r *Resolver) LookupTXT(_ context.Context, name string) ([]string, error) {
+	contents, _ := r.lookup(name, "TXT")
+	if len(contents) == 0 {
+		return nil, notFound(name)
+	}
+
+	return contents, nil
+}
+
+// LookupHost returns the addresses of the A and AAAA records of the name.
+func (r *Resolver) LookupHost(_ context.Context, host string) ([]string, error) {
+	addrs, _ := r.lookup(host, "A")
+	ipv6, _ := r.lookup(host, "AAAA")
+
+	addrs = append(addrs, ipv6...)
+	if len(addrs) == 0 {
+		return nil, notFound(host)
+	}
+
+	return addrs, nil
+}
+
+// LookupCNAME returns the target of the CNAME record of the name, the name itself when it has no CNAME record.
+func (r *Resolver) LookupCNAME(_ context.Context, host string) (string, error) {
+	contents, _ := r.lookup(host, "CNAME")
+	if len(contents) > 0 {
+		return fqdn(contents[0]), nil
+	}
+
+	if !r.exists(host) {
+		return "", notFound(host)
+	}
+
+	return fqdn(host), nil
+}
+
+// lookup returns the contents of the records of the name and type,
+// and whether the name is the apex of a zone.
+func (r *Resolver) lookup(name, recordType string) ([]string, bool) {
+	name = normalizeName(name)
+
+	r.server.mu.Lock()
+	defer r.server.mu.Unlock()
+
+	_, apex := r.server.zones[name]
+
+	var contents []string
+
+	for _, zone := range r.server.zones {
+		for _, record := range zone {
+			if normalizeName(record.Name) == name && strings.EqualFold(record.Type, recordType) {
+				contents = append(contents, record.Content)
+			}
+		}
+	}
+
+	return contents, apex
+}
+
+// exists returns true when the name has records or is the apex of a zone.
+func (r *Resolver) exists(name string) bool {
+	name = normalizeName(name)
+
+	r.server.mu.Lock()
+	defer r.server.mu.Unlock()
+
+	if _, ok := r.server.zones[name]; ok {
+		return true
+	}
+
+	for _, zone := range r.server.zones {
+		for _, record := range zone {
+			if normalizeName(record.Name) == name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func normalizeName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
+
+func fqdn(name string) string {
+	return strings.TrimSuffix(name, ".") + "."
+}
+
+func notFound(name string) error {
+	return &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
@@ -0,0 +1,69 @@
+package porkbuntest_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/nrdcg/porkbun"
+	"github.com/nrdcg/porkbun/porkbuntest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolver(t *testing.T) {
+	client, server := setupServer(t)
+
+	resolver := server.Resolver()
+
+	ctx := context.Background()
+
+	_, err := client.CreateRecord(ctx, "example.com", porkbun.Record{Name: "_acme-challenge", Type: "TXT", Content: "token"})
+	require.NoError(t, err)
+
+	_, err = client.CreateRecord(ctx, "example.com", porkbun.Record{Name: "www", Type: "CNAME", Content: "example.com"})
+	require.NoError(t, err)
+
+	txt, err := resolver.LookupTXT(ctx, "_acme-challenge.example.com.")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"token"}, txt)
+
+	addrs, err := resolver.LookupHost(ctx, "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1.1.1.1"}, addrs)
+
+	cname, err := resolver.LookupCNAME(ctx, "www.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "example.com.", cname)
+
+	_, err = resolver.LookupTXT(ctx, "missing.example.com")
+
+	dnsErr := &net.DNSError{}
+	require.ErrorAs(t, err, &dnsErr)
+	assert.True(t, dnsErr.IsNotFound)
+}
+
+func TestResolver_VerifyServedByPorkbun(t *testing.T) {
+	client, server := setupServer(t)
+	porkbun.WithResolver(server.Resolver())(client)
+
+	ctx := context.Background()
+
+	hosting, err := client.VerifyServedByPorkbun(ctx, "example.com")
+	require.NoError(t, err)
+
+	assert.True(t, hosting.ServedByPorkbun)
+	assert.Equal(t, porkbuntest.DefaultNameservers, hosting.Nameservers)
+
+	_, err = client.CreateRecord(ctx, "example.com", porkbun.Record{Type: "NS", Content: "ns1.example.net"})
+	require.NoError(t, err)
+
+	hosting, err = client.VerifyServedByPorkbun(ctx, "example.com")
+	require.NoError(t, err)
+
+	assert.False(t, hosting.ServedByPorkbun)
+	assert.Equal(t, []string{"ns1.example.net"}, hosting.Nameservers)
+
+	_, err = client.VerifyServedByPorkbun(ctx, "example.org")
+	require.Error(t, err)
+}
@@ -0,0 +1,248 @@
+package porkbuntest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const (
+	defaultTTL  = "600"
+	defaultPrio = "0"
+)
+
+var versionSegment = regexp.MustCompile(`^v\d+$`)
+
+// Record a DNS record of a zone of the fake server, as returned by the API.
+// The name is fully qualified (ex: "www.example.com").
+type Record struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Content string `json:"content"`
+	TTL     string `json:"ttl"`
+	Prio    string `json:"prio"`
+	Notes   string `json:"notes"`
+}
+
+// recordRequest the body of the create and edit requests.
+type recordRequest struct {
+	APIKey       string  `json:"apikey"`
+	SecretAPIKey string  `json:"secretapikey"`
+	Name         string  `json:"name"`
+	Type         string  `json:"type"`
+	Content      string  `json:"content"`
+	TTL          string  `json:"ttl"`
+	Prio         string  `json:"prio"`
+	Notes        *string `json:"notes"`
+}
+
+// Server a fake Porkbun API server keeping the DNS zones in memory.
+// It implements the ping endpoint and the create, edit, delete, and retrieve DNS endpoints,
+// with or without a version segment (the client BaseURL can be the server URL, with or without "/v3/").
+// The requests for a domain without zone fail, like the requests for a domain not in the account.
+// The zones can be resolved with the stub resolver returned by Server.Resolver (see porkbun.WithResolver).
+type Server struct {
+	*httptest.Server
+
+	mu     sync.Mutex
+	zones  map[string][]Record
+	nextID int64
+}
+
+// NewServer starts a fake server, closed at the end of the test.
+func NewServer(tb testing.TB) *Server {
+	tb.Helper()
+
+	s := &Server{zones: map[string][]Record{}, nextID: 1}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	tb.Cleanup(s.Close)
+
+	return s
+}
+
+// AddZone adds the zone of a domain, with its initial records.
+// The IDs of the records are generated when empty.
+func (s *Server) AddZone(domain string, records ...Record) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	zone := []Record{}
+
+	for _, record := range records {
+		if record.ID == "" {
+			record.ID = s.newID()
+		}
+
+		zone = append(zone, record)
+	}
+
+	s.zones[domain] = zone
+}
+
+// Zone returns the records of the zone of a domain, nil if the zone doesn't exist.
+func (s *Server) Zone(domain string) []Record {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	zone, ok := s.zones[domain]
+	if !ok {
+		return nil
+	}
+
+	return append([]Record{}, zone...)
+}
+
+func (s *Server) newID() string {
+	id := s.nextID
+	s.nextID++
+
+	return strconv.FormatInt(id, 10)
+}
+
+func (s *Server) serveHTTP(rw http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		writeError(rw, http.StatusMethodNotAllowed, "Invalid method.")
+		return
+	}
+
+	body := recordRequest{}
+
+	err := json.NewDecoder(req.Body).Decode(&body)
+	if err != nil || body.APIKey == "" || body.SecretAPIKey == "" {
+		writeError(rw, http.StatusBadRequest, "Invalid API key.")
+		return
+	}
+
+	// [/<version>]/<endpoint>/<params>
+	parts := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	if versionSegment.MatchString(parts[0]) {
+		parts = parts[1:]
+	}
+
+	if len(parts) == 0 {
+		writeError(rw, http.StatusNotFound, "Invalid endpoint.")
+		return
+	}
+
+	if parts[0] == "ping" {
+		writeJSON(rw, map[string]string{"status": "SUCCESS", "yourIp": "127.0.0.1"})
+		return
+	}
+
+	if len(parts) < 3 || parts[0] != "dns" {
+		writeError(rw, http.StatusNotFound, "Invalid endpoint.")
+		return
+	}
+
+	operation, domain, params := parts[1], parts[2], parts[3:]
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	zone, ok := s.zones[domain]
+	if !ok {
+		writeError(rw, http.StatusBadRequest, "Invalid domain.")
+		return
+	}
+
+	switch {
+	case operation == "retrieve" && len(params) == 0:
+		writeJSON(rw, map[string]interface{}{"status": "SUCCESS", "records": zone})
+
+	case operation == "retrieve" && len(params) == 1:
+		records := []Record{}
+		if i := findRecord(zone, params[0]); i >= 0 {
+			records = append(records, zone[i])
+		}
+
+		writeJSON(rw, map[string]interface{}{"status": "SUCCESS", "records": records})
+
+	case operation == "create" && len(params) == 0:
+		record := Record{ID: s.newID()}
+		body.apply(&record, domain)
+
+		s.zones[domain] = append(zone, record)
+
+		id, _ := strconv.ParseInt(record.ID, 10, 64)
+		writeJSON(rw, map[string]interface{}{"status": "SUCCESS", "id": id})
+
+	case operation == "edit" && len(params) == 1:
+		i := findRecord(zone, params[0])
+		if i < 0 {
+			writeError(rw, http.StatusBadRequest, "Invalid record ID.")
+			return
+		}
+
+		body.apply(&zone[i], domain)
+
+		writeJSON(rw, map[string]string{"status": "SUCCESS"})
+
+	case operation == "delete" && len(params) == 1:
+		i := findRecord(zone, params[0])
+		if i < 0 {
+			writeError(rw, http.StatusBadRequest, "Invalid record ID.")
+			return
+		}
+
+		s.zones[domain] = append(zone[:i:i], zone[i+1:]...)
+
+		writeJSON(rw, map[string]string{"status": "SUCCESS"})
+
+	default:
+		writeError(rw, http.StatusNotFound, "Invalid endpoint.")
+	}
+}
+
+// apply sets the fields of the request on the record, like the API: the missing TTL and priority get their default value.
+// The notes are kept when the request doesn't contain them.
+func (r recordRequest) apply(record *Record, domain string) {
+	record.Name = domain
+	if r.Name != "" {
+		record.Name = r.Name + "." + domain
+	}
+
+	record.Type = r.Type
+	record.Content = r.Content
+
+	record.TTL = r.TTL
+	if record.TTL == "" {
+		record.TTL = defaultTTL
+	}
+
+	record.Prio = r.Prio
+	if record.Prio == "" {
+		record.Prio = defaultPrio
+	}
+
+	if r.Notes != nil {
+		record.Notes = *r.Notes
+	}
+}
+
+func findRecord(zone []Record, id string) int {
+	for i, record := range zone {
+		if record.ID == id {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func writeJSON(rw http.ResponseWriter, data interface{}) {
+	rw.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(rw).Encode(data)
+}
+
+func writeError(rw http.ResponseWriter, code int, message string) {
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(code)
+	_ = json.NewEncoder(rw).Encode(map[string]string{"status": "ERROR", "message": message})
+}
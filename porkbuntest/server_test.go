@@ -0,0 +1,76 @@
+package porkbuntest_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/nrdcg/porkbun"
+	"github.com/nrdcg/porkbun/porkbuntest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupServer(t *testing.T) (*porkbun.Client, *porkbuntest.Server) {
+	t.Helper()
+
+	server := porkbuntest.NewServer(t)
+	server.AddZone("example.com", porkbuntest.Record{Name: "example.com", Type: "A", Content: "1.1.1.1", TTL: "600", Prio: "0"})
+
+	client := porkbun.New("secret", "key", porkbun.WithRecordsCacheTTL(0))
+	client.BaseURL, _ = url.Parse(server.URL)
+
+	return client, server
+}
+
+func TestServer(t *testing.T) {
+	client, server := setupServer(t)
+
+	ctx := context.Background()
+
+	_, err := client.Ping(ctx)
+	require.NoError(t, err)
+
+	id, err := client.CreateRecord(ctx, "example.com", porkbun.Record{Name: "www", Type: "CNAME", Content: "example.com", Notes: "website"})
+	require.NoError(t, err)
+
+	records, err := client.RetrieveRecords(ctx, "example.com")
+	require.NoError(t, err)
+
+	expected := []porkbun.Record{
+		{ID: 1, Name: "example.com", Type: "A", Content: "1.1.1.1", TTL: "600", Prio: "0"},
+		{ID: id, Name: "www.example.com", Type: "CNAME", Content: "example.com", TTL: "600", Prio: "0", Notes: "website"},
+	}
+
+	assert.Equal(t, expected, records)
+
+	err = client.EditRecord(ctx, "example.com", id, porkbun.Record{Name: "www", Type: "CNAME", Content: "www.example.net", TTL: "3600"})
+	require.NoError(t, err)
+
+	expectedZone := []porkbuntest.Record{
+		{ID: "1", Name: "example.com", Type: "A", Content: "1.1.1.1", TTL: "600", Prio: "0"},
+		{ID: id.String(), Name: "www.example.com", Type: "CNAME", Content: "www.example.net", TTL: "3600", Prio: "0", Notes: "website"},
+	}
+
+	assert.Equal(t, expectedZone, server.Zone("example.com"))
+
+	err = client.DeleteRecord(ctx, "example.com", 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, expectedZone[1:], server.Zone("example.com"))
+}
+
+func TestServer_errors(t *testing.T) {
+	client, _ := setupServer(t)
+
+	ctx := context.Background()
+
+	_, err := client.RetrieveRecords(ctx, "example.org")
+	require.Error(t, err)
+
+	err = client.DeleteRecord(ctx, "example.com", 666)
+	require.Error(t, err)
+
+	err = client.PatchRecord(ctx, "example.com", 666, porkbun.RecordPatch{})
+	require.ErrorIs(t, err, porkbun.ErrRecordNotFound)
+}
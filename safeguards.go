@@ -0,0 +1,98 @@
+package porkbun
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Confirmation a confirmation required by the safeguards to change a sensitive record.
+type Confirmation int
+
+// Confirmations.
+const (
+	// ConfirmWildcard confirms the creation, the modification, or the deletion of a wildcard (`*`) record.
+	ConfirmWildcard Confirmation = iota + 1
+	// ConfirmApexNS confirms the creation, the modification, or the deletion of an apex NS record.
+	ConfirmApexNS
+)
+
+func (c Confirmation) String() string {
+	switch c {
+	case ConfirmWildcard:
+		return "wildcard"
+	case ConfirmApexNS:
+		return "apex NS"
+	default:
+		return fmt.Sprintf("Confirmation(%d)", int(c))
+	}
+}
+
+// SafeguardError is returned when a change on a sensitive record is not confirmed.
+type SafeguardError struct {
+	Confirmation Confirmation
+	Domain       string
+	Record       Record
+}
+
+func (e SafeguardError) Error() string {
+	return fmt.Sprintf("porkbun: %s record change on %s requires confirmation", e.Confirmation, e.Domain)
+}
+
+type confirmationsKey struct{}
+
+// WithConfirmation returns a context confirming the changes on sensitive records,
+// used by the client created with WithSafeguards.
+func WithConfirmation(ctx context.Context, confirmations ...Confirmation) context.Context {
+	current, _ := ctx.Value(confirmationsKey{}).([]Confirmation)
+
+	return context.WithValue(ctx, confirmationsKey{}, append(append([]Confirmation{}, current...), confirmations...))
+}
+
+func confirmed(ctx context.Context, confirmation Confirmation) bool {
+	confirmations, _ := ctx.Value(confirmationsKey{}).([]Confirmation)
+
+	return slices.Contains(confirmations, confirmation)
+}
+
+// guardRecord checks that the change on a record is confirmed, if the record is sensitive.
+// The record name is the subdomain.
+func (c *Client) guardRecord(ctx context.Context, domain string, record Record) error {
+	if !c.safeguards {
+		return nil
+	}
+
+	var required Confirmation
+
+	switch {
+	case record.Name == "*" || strings.HasPrefix(record.Name, "*."):
+		required = ConfirmWildcard
+	case record.Name == "" && strings.EqualFold(record.Type, "NS"):
+		required = ConfirmApexNS
+	default:
+		return nil
+	}
+
+	if confirmed(ctx, required) {
+		return nil
+	}
+
+	return &SafeguardError{Confirmation: required, Domain: domain, Record: record}
+}
+
+// guardExistingRecord checks that the change on an existing record is confirmed, if the record is sensitive.
+func (c *Client) guardExistingRecord(ctx context.Context, domain string, id RecordID) error {
+	if !c.safeguards {
+		return nil
+	}
+
+	current, err := c.retrieveRecord(ctx, domain, id)
+	if err != nil {
+		return err
+	}
+
+	current.Name = subDomain(current.Name, domain)
+
+	return c.guardRecord(ctx, domain, current)
+}
@@ -0,0 +1,79 @@
+package porkbun
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithSafeguards_create(t *testing.T) {
+	client := setup(t, "/dns/create/example.com", "create")
+	WithSafeguards()(client)
+
+	record := Record{Name: "*", Type: "A", Content: "1.1.1.1", TTL: DefaultTTL}
+
+	_, err := client.CreateRecord(context.Background(), "example.com", record)
+
+	safeguardErr := &SafeguardError{}
+	require.ErrorAs(t, err, &safeguardErr)
+	assert.Equal(t, ConfirmWildcard, safeguardErr.Confirmation)
+
+	ctx := WithConfirmation(context.Background(), ConfirmWildcard)
+
+	_, err = client.CreateRecord(ctx, "example.com", record)
+	require.NoError(t, err)
+}
+
+func TestWithSafeguards_create_apexNS(t *testing.T) {
+	client := setup(t, "/dns/create/example.com", "create")
+	WithSafeguards()(client)
+
+	record := Record{Type: "NS", Content: "ns1.example.net"}
+
+	ctx := WithConfirmation(context.Background(), ConfirmWildcard)
+
+	_, err := client.CreateRecord(ctx, "example.com", record)
+
+	safeguardErr := &SafeguardError{}
+	require.ErrorAs(t, err, &safeguardErr)
+	assert.Equal(t, ConfirmApexNS, safeguardErr.Confirmation)
+
+	_, err = client.CreateRecord(WithConfirmation(ctx, ConfirmApexNS), "example.com", record)
+	require.NoError(t, err)
+}
+
+func TestWithSafeguards_delete(t *testing.T) {
+	client, mux := setupMux(t)
+	WithSafeguards()(client)
+
+	mux.HandleFunc("/dns/retrieve/example.com/666", fixtureHandler("retrieve-ns"))
+	mux.HandleFunc("/dns/delete/example.com/666", fixtureHandler("delete"))
+
+	err := client.DeleteRecord(context.Background(), "example.com", 666)
+
+	safeguardErr := &SafeguardError{}
+	require.ErrorAs(t, err, &safeguardErr)
+	assert.Equal(t, ConfirmApexNS, safeguardErr.Confirmation)
+
+	err = client.DeleteRecord(WithConfirmation(context.Background(), ConfirmApexNS), "example.com", 666)
+	require.NoError(t, err)
+}
+
+func TestWithSafeguards_edit(t *testing.T) {
+	client, mux := setupMux(t)
+	WithSafeguards()(client)
+
+	mux.HandleFunc("/dns/retrieve/example.com/666", fixtureHandler("retrieve-id"))
+	mux.HandleFunc("/dns/edit/example.com/666", fixtureHandler("edit"))
+
+	err := client.EditRecord(context.Background(), "example.com", 666, Record{Name: "www", Type: "A", Content: "2.2.2.2"})
+	require.NoError(t, err)
+
+	err = client.EditRecord(context.Background(), "example.com", 666, Record{Name: "*.www", Type: "A", Content: "2.2.2.2"})
+
+	safeguardErr := &SafeguardError{}
+	require.ErrorAs(t, err, &safeguardErr)
+	assert.Equal(t, ConfirmWildcard, safeguardErr.Confirmation)
+}
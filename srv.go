@@ -0,0 +1,107 @@
+package porkbun
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SRVTarget a target of a SRV record set.
+type SRVTarget struct {
+	Priority int
+	Weight   int
+	Port     int
+	Target   string
+}
+
+func (t SRVTarget) record(name string) Record {
+	return Record{
+		Name:    name,
+		Type:    "SRV",
+		Content: fmt.Sprintf("%d %d %s", t.Weight, t.Port, t.Target),
+		TTL:     DefaultTTL,
+		Prio:    strconv.Itoa(t.Priority),
+	}
+}
+
+// EnsureService manages the SRV records of `_service._proto` as one set.
+// The missing targets are created first, then the records not matching a target and the duplicate records are deleted.
+// An empty targets list deletes the whole set.
+//
+//	service: the service name, with or without the leading underscore (ex: "sip", "_sip").
+//	proto: the protocol, with or without the leading underscore (ex: "tcp", "_tcp").
+func (c *Client) EnsureService(ctx context.Context, domain, service, proto string, targets []SRVTarget) error {
+	if c.readOnly {
+		return ErrReadOnly
+	}
+
+	name := "_" + strings.TrimPrefix(service, "_") + "._" + strings.TrimPrefix(proto, "_")
+
+	records, err := c.RetrieveRecords(ctx, domain)
+	if err != nil {
+		return err
+	}
+
+	var current []Record
+
+	existing := map[string][]Record{}
+
+	for _, record := range records {
+		if record.Type != "SRV" || subDomain(record.Name, domain) != name {
+			continue
+		}
+
+		current = append(current, record)
+
+		key := srvKey(record)
+		existing[key] = append(existing[key], record)
+	}
+
+	desired := map[string]struct{}{}
+
+	for _, target := range targets {
+		record := target.record(name)
+
+		key := srvKey(record)
+		if _, ok := desired[key]; ok {
+			continue
+		}
+
+		desired[key] = struct{}{}
+
+		if len(existing[key]) > 0 {
+			continue
+		}
+
+		_, err = c.CreateRecord(ctx, domain, record)
+		if err != nil {
+			return fmt.Errorf("failed to create SRV record %q: %w", record.Content, err)
+		}
+	}
+
+	// the first record of each desired target is kept, the other records and the duplicates are deleted.
+	kept := map[string]struct{}{}
+
+	for _, record := range current {
+		key := srvKey(record)
+
+		if _, ok := desired[key]; ok {
+			if _, ok := kept[key]; !ok {
+				kept[key] = struct{}{}
+				continue
+			}
+		}
+
+		err = c.DeleteRecord(ctx, domain, record.ID)
+		if err != nil {
+			return fmt.Errorf("failed to delete SRV record %s: %w", record.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func srvKey(record Record) string {
+	return record.Prio + " " + strings.TrimSuffix(record.Content, ".")
+}
@@ -0,0 +1,97 @@
+package porkbun
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/nrdcg/porkbun/porkbuntest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_EnsureService(t *testing.T) {
+	client, mux := setupMux(t)
+
+	mux.HandleFunc("/dns/retrieve/example.com", fixtureHandler("retrieve-srv"))
+
+	var created []Record
+
+	mux.HandleFunc("/dns/create/example.com", func(rw http.ResponseWriter, req *http.Request) {
+		record := Record{}
+
+		err := json.NewDecoder(req.Body).Decode(&record)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		created = append(created, record)
+
+		fixtureHandler("create")(rw, req)
+	})
+
+	var deleted []string
+
+	mux.HandleFunc("/dns/delete/example.com/", func(rw http.ResponseWriter, req *http.Request) {
+		deleted = append(deleted, strings.TrimPrefix(req.URL.Path, "/dns/delete/example.com/"))
+
+		fixtureHandler("delete")(rw, req)
+	})
+
+	targets := []SRVTarget{
+		{Priority: 10, Weight: 5, Port: 5060, Target: "sip1.example.com"},
+		{Priority: 20, Weight: 5, Port: 5060, Target: "sip3.example.com"},
+	}
+
+	err := client.EnsureService(context.Background(), "example.com", "sip", "_tcp", targets)
+	require.NoError(t, err)
+
+	expected := []Record{{
+		Name:    "_sip._tcp",
+		Type:    "SRV",
+		Content: "5 5060 sip3.example.com",
+		TTL:     DefaultTTL,
+		Prio:    "20",
+	}}
+
+	assert.Equal(t, expected, created)
+	assert.Equal(t, []string{"106926654"}, deleted)
+}
+
+func TestClient_EnsureService_duplicates(t *testing.T) {
+	server := porkbuntest.NewServer(t)
+	server.AddZone("example.com",
+		porkbuntest.Record{ID: "1", Name: "_sip._tcp.example.com", Type: "SRV", Content: "5 5060 old.example.com", TTL: "600", Prio: "10"},
+		porkbuntest.Record{ID: "2", Name: "_sip._tcp.example.com", Type: "SRV", Content: "5 5060 old.example.com", TTL: "600", Prio: "10"},
+		porkbuntest.Record{ID: "3", Name: "_sip._tcp.example.com", Type: "SRV", Content: "5 5060 sip1.example.com", TTL: "600", Prio: "10"},
+		porkbuntest.Record{ID: "4", Name: "_sip._tcp.example.com", Type: "SRV", Content: "5 5060 sip1.example.com.", TTL: "600", Prio: "10"},
+	)
+
+	client := New("secret", "key")
+	client.BaseURL, _ = url.Parse(server.URL)
+
+	targets := []SRVTarget{
+		{Priority: 10, Weight: 5, Port: 5060, Target: "sip1.example.com"},
+		{Priority: 10, Weight: 5, Port: 5060, Target: "sip1.example.com"},
+	}
+
+	err := client.EnsureService(context.Background(), "example.com", "sip", "tcp", targets)
+	require.NoError(t, err)
+
+	expected := []porkbuntest.Record{
+		{ID: "3", Name: "_sip._tcp.example.com", Type: "SRV", Content: "5 5060 sip1.example.com", TTL: "600", Prio: "10"},
+	}
+
+	assert.Equal(t, expected, server.Zone("example.com"))
+}
+
+func TestClient_EnsureService_error(t *testing.T) {
+	client := setup(t, "/dns/retrieve/example.com", "error")
+
+	err := client.EnsureService(context.Background(), "example.com", "sip", "tcp", nil)
+	require.Error(t, err)
+}
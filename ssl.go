@@ -0,0 +1,189 @@
+package porkbun
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// SSLCheck a check of the SSL bundle verification.
+type SSLCheck string
+
+// SSL bundle checks.
+const (
+	SSLCheckParse    SSLCheck = "parse"
+	SSLCheckChain    SSLCheck = "chain"
+	SSLCheckDomain   SSLCheck = "domain"
+	SSLCheckWildcard SSLCheck = "wildcard"
+	SSLCheckKey      SSLCheck = "key"
+	SSLCheckExpiry   SSLCheck = "expiry"
+)
+
+const defaultSSLMinValidity = 7 * 24 * time.Hour
+
+// SSLVerifyOptions the options of the SSL bundle verification.
+type SSLVerifyOptions struct {
+	// MinValidity the minimum remaining validity of the certificate (7 days by default), a negative value disables the check.
+	MinValidity time.Duration
+}
+
+// SSLFinding a problem found by the SSL bundle verification.
+type SSLFinding struct {
+	Check   SSLCheck
+	Message string
+}
+
+func (f SSLFinding) String() string {
+	return fmt.Sprintf("%s: %s", f.Check, f.Message)
+}
+
+// Verify checks that the bundle can be deployed for the domain:
+// the chain builds to a trusted root, the certificate covers the domain and its wildcard,
+// the private key matches the certificate, and the certificate is valid for at least 7 days.
+// It returns the problems found, none when the bundle is valid.
+func (b SSLBundle) Verify(domain string) []SSLFinding {
+	return b.VerifyWithOptions(domain, SSLVerifyOptions{})
+}
+
+// VerifyWithOptions checks that the bundle can be deployed for the domain, like Verify, with custom options.
+func (b SSLBundle) VerifyWithOptions(domain string, opts SSLVerifyOptions) []SSLFinding {
+	return b.verify(domain, nil, time.Now(), opts)
+}
+
+func (b SSLBundle) verify(domain string, roots *x509.CertPool, now time.Time, opts SSLVerifyOptions) []SSLFinding {
+	minValidity := opts.MinValidity
+	if minValidity == 0 {
+		minValidity = defaultSSLMinValidity
+	}
+
+	chain, err := parseCertificates(b.CertificateChain)
+	if err != nil {
+		return []SSLFinding{{Check: SSLCheckParse, Message: fmt.Sprintf("certificate chain: %v", err)}}
+	}
+
+	if len(chain) == 0 {
+		return []SSLFinding{{Check: SSLCheckParse, Message: "certificate chain: no certificate found"}}
+	}
+
+	intermediates, err := parseCertificates(b.IntermediateCertificate)
+	if err != nil {
+		return []SSLFinding{{Check: SSLCheckParse, Message: fmt.Sprintf("intermediate certificate: %v", err)}}
+	}
+
+	leaf := chain[0]
+
+	var findings []SSLFinding
+
+	pool := x509.NewCertPool()
+	for _, cert := range chain[1:] {
+		pool.AddCert(cert)
+	}
+
+	for _, cert := range intermediates {
+		pool.AddCert(cert)
+	}
+
+	_, err = leaf.Verify(x509.VerifyOptions{Roots: roots, Intermediates: pool, CurrentTime: now})
+	if err != nil {
+		findings = append(findings, SSLFinding{Check: SSLCheckChain, Message: err.Error()})
+	}
+
+	err = leaf.VerifyHostname(domain)
+	if err != nil {
+		findings = append(findings, SSLFinding{Check: SSLCheckDomain, Message: err.Error()})
+	}
+
+	if !slices.Contains(leaf.DNSNames, "*."+domain) {
+		findings = append(findings, SSLFinding{Check: SSLCheckWildcard, Message: fmt.Sprintf("the certificate doesn't cover *.%s", domain)})
+	}
+
+	err = verifyPrivateKey(b.PrivateKey, leaf)
+	if err != nil {
+		findings = append(findings, SSLFinding{Check: SSLCheckKey, Message: err.Error()})
+	}
+
+	switch {
+	case now.Before(leaf.NotBefore):
+		findings = append(findings, SSLFinding{Check: SSLCheckExpiry, Message: fmt.Sprintf("the certificate is not valid before %s", leaf.NotBefore)})
+	case now.After(leaf.NotAfter):
+		findings = append(findings, SSLFinding{Check: SSLCheckExpiry, Message: fmt.Sprintf("the certificate expired on %s", leaf.NotAfter)})
+	case minValidity > 0 && leaf.NotAfter.Sub(now) < minValidity:
+		findings = append(findings, SSLFinding{Check: SSLCheckExpiry, Message: fmt.Sprintf("the certificate expires on %s, in less than %s", leaf.NotAfter, minValidity)})
+	}
+
+	return findings
+}
+
+func parseCertificates(data string) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+
+	rest := []byte(data)
+
+	for {
+		var block *pem.Block
+
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		certs = append(certs, cert)
+	}
+
+	return certs, nil
+}
+
+func verifyPrivateKey(data string, leaf *x509.Certificate) error {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return errors.New("no private key found")
+	}
+
+	key, err := parsePrivateKey(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return fmt.Errorf("unsupported private key type %T", key)
+	}
+
+	public, ok := signer.Public().(interface{ Equal(x crypto.PublicKey) bool })
+	if !ok || !public.Equal(leaf.PublicKey) {
+		return errors.New("the private key doesn't match the certificate")
+	}
+
+	return nil
+}
+
+// parsePrivateKey parses a private key in the PKCS #8, PKCS #1 (RSA), or SEC 1 (EC) form.
+func parsePrivateKey(der []byte) (crypto.PrivateKey, error) {
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err == nil {
+		return key, nil
+	}
+
+	if rsaKey, errRSA := x509.ParsePKCS1PrivateKey(der); errRSA == nil {
+		return rsaKey, nil
+	}
+
+	if ecKey, errEC := x509.ParseECPrivateKey(der); errEC == nil {
+		return ecKey, nil
+	}
+
+	return nil, err
+}
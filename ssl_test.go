@@ -0,0 +1,239 @@
+package porkbun
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testPKI struct {
+	roots        *x509.CertPool
+	intermediate string
+	leaf         string
+	key          string
+	otherKey     string
+}
+
+func newTestPKI(t *testing.T, dnsNames ...string) testPKI {
+	t.Helper()
+
+	notBefore := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	notAfter := notBefore.AddDate(1, 0, 0)
+
+	rootKey := newTestKey(t)
+	rootTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test Root"},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	root := createTestCertificate(t, rootTmpl, rootTmpl, rootKey.Public(), rootKey)
+
+	interKey := newTestKey(t)
+	interTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(2),
+		Subject:               pkix.Name{CommonName: "Test Intermediate"},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	inter := createTestCertificate(t, interTmpl, root, interKey.Public(), rootKey)
+
+	leafKey := newTestKey(t)
+	leafTmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: dnsNames[0]},
+		DNSNames:     dnsNames,
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	leaf := createTestCertificate(t, leafTmpl, inter, leafKey.Public(), interKey)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(root)
+
+	return testPKI{
+		roots:        roots,
+		intermediate: encodeTestCertificate(inter),
+		leaf:         encodeTestCertificate(leaf),
+		key:          encodeTestKey(t, leafKey),
+		otherKey:     encodeTestKey(t, newTestKey(t)),
+	}
+}
+
+func newTestKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	return key
+}
+
+func createTestCertificate(t *testing.T, tmpl, parent *x509.Certificate, pub crypto.PublicKey, signer crypto.Signer) *x509.Certificate {
+	t.Helper()
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, pub, signer)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return cert
+}
+
+func encodeTestCertificate(cert *x509.Certificate) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
+}
+
+func encodeTestKey(t *testing.T, key *ecdsa.PrivateKey) string {
+	t.Helper()
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+}
+
+func TestSSLBundle_verify(t *testing.T) {
+	pki := newTestPKI(t, "example.com", "*.example.com")
+
+	bundle := SSLBundle{
+		IntermediateCertificate: pki.intermediate,
+		CertificateChain:        pki.leaf + "\n" + pki.intermediate,
+		PrivateKey:              pki.key,
+	}
+
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	findings := bundle.verify("example.com", pki.roots, now, SSLVerifyOptions{})
+	assert.Empty(t, findings)
+}
+
+func TestSSLBundle_verify_findings(t *testing.T) {
+	pki := newTestPKI(t, "www.example.com")
+
+	bundle := SSLBundle{
+		CertificateChain: pki.leaf,
+		PrivateKey:       pki.otherKey,
+	}
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var checks []SSLCheck
+	for _, finding := range bundle.verify("example.com", pki.roots, now, SSLVerifyOptions{}) {
+		checks = append(checks, finding.Check)
+	}
+
+	expected := []SSLCheck{SSLCheckChain, SSLCheckDomain, SSLCheckWildcard, SSLCheckKey, SSLCheckExpiry}
+
+	assert.Equal(t, expected, checks)
+}
+
+func TestSSLBundle_verify_minValidity(t *testing.T) {
+	pki := newTestPKI(t, "example.com", "*.example.com")
+
+	bundle := SSLBundle{
+		CertificateChain: pki.leaf + "\n" + pki.intermediate,
+		PrivateKey:       pki.key,
+	}
+
+	// the certificates expire on 2025-01-01.
+	now := time.Date(2024, 12, 28, 0, 0, 0, 0, time.UTC)
+
+	findings := bundle.verify("example.com", pki.roots, now, SSLVerifyOptions{})
+
+	require.Len(t, findings, 1)
+	assert.Equal(t, SSLCheckExpiry, findings[0].Check)
+
+	findings = bundle.verify("example.com", pki.roots, now, SSLVerifyOptions{MinValidity: 72 * time.Hour})
+	assert.Empty(t, findings)
+
+	findings = bundle.verify("example.com", pki.roots, now, SSLVerifyOptions{MinValidity: 30 * 24 * time.Hour})
+	require.Len(t, findings, 1)
+
+	findings = bundle.verify("example.com", pki.roots, now, SSLVerifyOptions{MinValidity: -1})
+	assert.Empty(t, findings)
+}
+
+func Test_verifyPrivateKey(t *testing.T) {
+	ecKey := newTestKey(t)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "example.com"}}
+
+	ecDER, err := x509.MarshalECPrivateKey(ecKey)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc string
+		leaf *x509.Certificate
+		key  string
+	}{
+		{
+			desc: "PKCS #8",
+			leaf: createTestCertificate(t, tmpl, tmpl, ecKey.Public(), ecKey),
+			key:  encodeTestKey(t, ecKey),
+		},
+		{
+			desc: "PKCS #1",
+			leaf: createTestCertificate(t, tmpl, tmpl, rsaKey.Public(), rsaKey),
+			key:  string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)})),
+		},
+		{
+			desc: "SEC 1",
+			leaf: createTestCertificate(t, tmpl, tmpl, ecKey.Public(), ecKey),
+			key:  string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: ecDER})),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			err := verifyPrivateKey(test.key, test.leaf)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_verifyPrivateKey_invalid(t *testing.T) {
+	key := newTestKey(t)
+
+	tmpl := &x509.Certificate{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "example.com"}}
+	leaf := createTestCertificate(t, tmpl, tmpl, key.Public(), key)
+
+	err := verifyPrivateKey(string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("invalid")})), leaf)
+	require.ErrorContains(t, err, "failed to parse private key")
+}
+
+func TestSSLBundle_Verify_parse(t *testing.T) {
+	client := setup(t, "/ssl/retrieve/example.com", "ssl-bundle")
+
+	bundle, err := client.RetrieveSSLBundle(context.Background(), "example.com")
+	require.NoError(t, err)
+
+	findings := bundle.Verify("example.com")
+
+	require.Len(t, findings, 1)
+	assert.Equal(t, SSLCheckParse, findings[0].Check)
+}
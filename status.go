@@ -0,0 +1,97 @@
+package porkbun
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// StatusChecker checks the status of the Porkbun services (ex: with the status page),
+// it returns the description of the ongoing incident, or an empty string when there is no incident.
+type StatusChecker func(ctx context.Context) (string, error)
+
+// IncidentError an API error while the provider reports an ongoing incident (see WithStatusChecker).
+type IncidentError struct {
+	Incident string
+	Err      error
+}
+
+func (e IncidentError) Error() string {
+	return fmt.Sprintf("%v (provider reports ongoing incident: %s)", e.Err, e.Incident)
+}
+
+func (e IncidentError) Unwrap() error {
+	return e.Err
+}
+
+// statusCheckInterval the duration during which the result of the status checker is reused.
+const statusCheckInterval = time.Minute
+
+type statusTracker struct {
+	threshold int
+	checker   StatusChecker
+
+	mu        sync.Mutex
+	failures  int
+	checkedAt time.Time
+	incident  string
+}
+
+// success resets the consecutive server errors.
+func (s *statusTracker) success() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = 0
+}
+
+// failure counts a server error (5xx), the other errors reset the consecutive server errors.
+// From the threshold of consecutive server errors, the error is wrapped with the ongoing incident reported by the status checker, if any.
+func (s *statusTracker) failure(ctx context.Context, err *ServerError, now time.Time) error {
+	if s.checker == nil {
+		return err
+	}
+
+	if err.StatusCode < http.StatusInternalServerError {
+		s.success()
+		return err
+	}
+
+	s.mu.Lock()
+	s.failures++
+	reached := s.failures >= s.threshold
+	fresh := !s.checkedAt.IsZero() && now.Sub(s.checkedAt) < statusCheckInterval
+	incident := s.incident
+	s.mu.Unlock()
+
+	if !reached {
+		return err
+	}
+
+	if !fresh {
+		incident = s.check(ctx, now)
+	}
+
+	if incident == "" {
+		return err
+	}
+
+	return &IncidentError{Incident: incident, Err: err}
+}
+
+// check consults the status checker, the result is reused during statusCheckInterval.
+func (s *statusTracker) check(ctx context.Context, now time.Time) string {
+	incident, err := s.checker(ctx)
+	if err != nil {
+		incident = ""
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.checkedAt, s.incident = now, incident
+
+	return incident
+}
@@ -0,0 +1,168 @@
+package porkbun
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithStatusChecker(t *testing.T) {
+	client, mux := setupMux(t)
+
+	var checks int
+
+	WithStatusChecker(2, func(_ context.Context) (string, error) {
+		checks++
+
+		return "DNS API degraded", nil
+	})(client)
+
+	failing := true
+
+	mux.HandleFunc("/ping", func(rw http.ResponseWriter, req *http.Request) {
+		if failing {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		fixtureHandler("ping")(rw, req)
+	})
+
+	ctx := context.Background()
+
+	_, err := client.Ping(ctx)
+	require.Error(t, err)
+
+	incidentErr := &IncidentError{}
+	assert.False(t, errors.As(err, &incidentErr))
+	assert.Equal(t, 0, checks)
+
+	_, err = client.Ping(ctx)
+	require.ErrorAs(t, err, &incidentErr)
+	assert.Equal(t, "DNS API degraded", incidentErr.Incident)
+	require.ErrorContains(t, err, "provider reports ongoing incident: DNS API degraded")
+
+	serverErr := &ServerError{}
+	require.ErrorAs(t, err, &serverErr)
+	assert.Equal(t, http.StatusServiceUnavailable, serverErr.StatusCode)
+
+	assert.Equal(t, 1, checks)
+
+	// a success resets the consecutive server errors.
+	failing = false
+
+	_, err = client.Ping(ctx)
+	require.NoError(t, err)
+
+	failing = true
+
+	_, err = client.Ping(ctx)
+	assert.False(t, errors.As(err, &incidentErr))
+	assert.Equal(t, 1, checks)
+}
+
+func TestWithStatusChecker_noIncident(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		checker StatusChecker
+	}{
+		{
+			desc: "no incident",
+			checker: func(_ context.Context) (string, error) {
+				return "", nil
+			},
+		},
+		{
+			desc: "checker error",
+			checker: func(_ context.Context) (string, error) {
+				return "", errors.New("status page unavailable")
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			client, mux := setupMux(t)
+			WithStatusChecker(1, test.checker)(client)
+
+			mux.HandleFunc("/ping", func(rw http.ResponseWriter, _ *http.Request) {
+				rw.WriteHeader(http.StatusInternalServerError)
+			})
+
+			_, err := client.Ping(context.Background())
+
+			serverErr := &ServerError{}
+			require.ErrorAs(t, err, &serverErr)
+
+			incidentErr := &IncidentError{}
+			assert.False(t, errors.As(err, &incidentErr))
+		})
+	}
+}
+
+func TestWithStatusChecker_clientError(t *testing.T) {
+	client := setup(t, "/ping", "ping")
+
+	var checks int
+
+	WithStatusChecker(1, func(_ context.Context) (string, error) {
+		checks++
+
+		return "DNS API degraded", nil
+	})(client)
+
+	for _, code := range []int{http.StatusBadRequest, http.StatusNotFound, http.StatusTooManyRequests} {
+		err := client.status.failure(context.Background(), &ServerError{StatusCode: code}, time.Now())
+
+		incidentErr := &IncidentError{}
+		assert.False(t, errors.As(err, &incidentErr))
+	}
+
+	// the test server has no handler for this endpoint: 404.
+	_, err := client.RetrieveRecords(context.Background(), "example.com")
+	require.Error(t, err)
+
+	assert.Equal(t, 0, checks)
+}
+
+func TestWithStatusChecker_cache(t *testing.T) {
+	client, mux := setupMux(t)
+
+	clock := newFakeClock()
+	WithClock(clock)(client)
+
+	var checks int
+
+	WithStatusChecker(1, func(_ context.Context) (string, error) {
+		checks++
+
+		return "DNS API degraded", nil
+	})(client)
+
+	mux.HandleFunc("/ping", func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusBadGateway)
+	})
+
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		_, err := client.Ping(ctx)
+
+		incidentErr := &IncidentError{}
+		require.ErrorAs(t, err, &incidentErr)
+	}
+
+	assert.Equal(t, 1, checks)
+
+	clock.Advance(statusCheckInterval)
+
+	_, err := client.Ping(ctx)
+	require.Error(t, err)
+
+	assert.Equal(t, 2, checks)
+}
@@ -0,0 +1,179 @@
+package porkbun
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultSuggestInterval    = 10 * time.Second
+	defaultSuggestMaxInterval = 5 * time.Minute
+	defaultSuggestMaxRetries  = 5
+)
+
+// SuggestOptions the options of SuggestAvailable.
+type SuggestOptions struct {
+	// Prefixes the prefixes added to the base name to generate candidates (ex: "get", "try").
+	Prefixes []string
+	// Suffixes the suffixes added to the base name to generate candidates (ex: "app", "hq").
+	Suffixes []string
+	// MaxPrice the maximum registration price, 0 for no limit.
+	MaxPrice float64
+	// AllowPremium includes the premium domains.
+	AllowPremium bool
+	// Interval the interval between two checks (10 seconds by default).
+	Interval time.Duration
+	// MaxInterval the maximum interval when the checks are rate limited (5 minutes by default).
+	MaxInterval time.Duration
+	// MaxRetries the maximum number of retries of a rate limited check (5 by default),
+	// the candidate is then sent with the error.
+	MaxRetries int
+}
+
+// Suggestion an available domain, or the failure of the check of a candidate.
+type Suggestion struct {
+	Domain       string
+	Price        float64
+	Availability Availability
+	Err          error
+}
+
+// SuggestAvailable checks the availability of the candidates generated from the base name and the TLDs,
+// and streams the available domains matching the options.
+// The checks are paced by SuggestOptions.Interval, the interval doubles when the API rate limits the checks,
+// a rate limited check is retried up to SuggestOptions.MaxRetries times.
+// A candidate that cannot be checked is sent with an error.
+// The channel is closed when all the candidates are checked or when the context is done.
+func (c *Client) SuggestAvailable(ctx context.Context, base string, tlds []string, opts SuggestOptions) <-chan Suggestion {
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = defaultSuggestInterval
+	}
+
+	maxInterval := opts.MaxInterval
+	if maxInterval <= 0 {
+		maxInterval = defaultSuggestMaxInterval
+	}
+
+	maxRetries := opts.MaxRetries
+	if maxRetries <= 0 {
+		maxRetries = defaultSuggestMaxRetries
+	}
+
+	candidates := suggestCandidates(base, tlds, opts)
+
+	suggestions := make(chan Suggestion)
+
+	go func() {
+		defer close(suggestions)
+
+		wait := time.Duration(0)
+		retries := 0
+
+		for i := 0; i < len(candidates); {
+			select {
+			case <-ctx.Done():
+				return
+			case <-c.getClock().After(wait):
+			}
+
+			domain := candidates[i]
+
+			availability, err := c.CheckDomain(ctx, domain)
+			if isRateLimited(err) && retries < maxRetries {
+				wait = min(2*max(wait, interval), maxInterval)
+				retries++
+
+				continue
+			}
+
+			wait = interval
+			retries = 0
+			i++
+
+			suggestion, ok := suggest(domain, availability, err, opts)
+			if !ok {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case suggestions <- suggestion:
+			}
+		}
+	}()
+
+	return suggestions
+}
+
+func suggest(domain string, availability Availability, err error, opts SuggestOptions) (Suggestion, bool) {
+	if err != nil {
+		return Suggestion{Domain: domain, Err: err}, true
+	}
+
+	if !availability.Available {
+		return Suggestion{}, false
+	}
+
+	if bool(availability.Premium) && !opts.AllowPremium {
+		return Suggestion{}, false
+	}
+
+	price, err := strconv.ParseFloat(availability.Price, 64)
+	if err != nil {
+		return Suggestion{Domain: domain, Err: fmt.Errorf("invalid price %q: %w", availability.Price, err)}, true
+	}
+
+	if opts.MaxPrice > 0 && price > opts.MaxPrice {
+		return Suggestion{}, false
+	}
+
+	return Suggestion{Domain: domain, Price: price, Availability: availability}, true
+}
+
+func suggestCandidates(base string, tlds []string, opts SuggestOptions) []string {
+	names := []string{base}
+
+	for _, prefix := range opts.Prefixes {
+		names = append(names, prefix+base)
+	}
+
+	for _, suffix := range opts.Suffixes {
+		names = append(names, base+suffix)
+	}
+
+	seen := map[string]struct{}{}
+
+	var candidates []string
+
+	for _, name := range names {
+		for _, tld := range tlds {
+			candidate := strings.ToLower(name + "." + strings.TrimPrefix(tld, "."))
+
+			if _, ok := seen[candidate]; ok {
+				continue
+			}
+
+			seen[candidate] = struct{}{}
+
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	return candidates
+}
+
+func isRateLimited(err error) bool {
+	serverErr := &ServerError{}
+	if !errors.As(err, &serverErr) {
+		return false
+	}
+
+	return serverErr.StatusCode == http.StatusTooManyRequests || serverErr.StatusCode == http.StatusServiceUnavailable
+}
@@ -0,0 +1,248 @@
+package porkbun
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingClock a fake clock recording the durations of the waits.
+type recordingClock struct {
+	*fakeClock
+
+	mu    sync.Mutex
+	waits []time.Duration
+}
+
+func (r *recordingClock) After(d time.Duration) <-chan time.Time {
+	r.mu.Lock()
+	r.waits = append(r.waits, d)
+	r.mu.Unlock()
+
+	return r.fakeClock.After(d)
+}
+
+func (r *recordingClock) Waits() []time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]time.Duration(nil), r.waits...)
+}
+
+// availabilityHandler responds to the availability checks with the availability of each domain,
+// the unknown domains are not available.
+func availabilityHandler(availabilities map[string]Availability) http.HandlerFunc {
+	return func(rw http.ResponseWriter, req *http.Request) {
+		availability := availabilities[path.Base(req.URL.Path)]
+
+		_, _ = fmt.Fprintf(rw, `{"status":"SUCCESS","response":{"avail":%q,"type":"registration","price":%q,"premium":%q}}`,
+			flagValue(availability.Available), availability.Price, flagValue(availability.Premium))
+	}
+}
+
+func flagValue(f Flag) string {
+	if f {
+		return "yes"
+	}
+
+	return "no"
+}
+
+func collectSuggestions(suggestions <-chan Suggestion) []Suggestion {
+	var all []Suggestion
+
+	for suggestion := range suggestions {
+		all = append(all, suggestion)
+	}
+
+	return all
+}
+
+func TestClient_SuggestAvailable(t *testing.T) {
+	client, mux := setupMux(t)
+	WithClock(newFakeClock())(client)
+
+	mux.HandleFunc("/domain/checkDomain/", availabilityHandler(map[string]Availability{
+		"example.com":    {Available: true, Price: "9.68"},
+		"getexample.com": {Available: true, Price: "99.00"},
+		"example.dev":    {Available: true, Price: "12.00", Premium: true},
+		"exampleapp.dev": {Available: true, Price: "n/a"},
+		"exampleapp.com": {Available: true, Price: "11.00"},
+	}))
+
+	opts := SuggestOptions{
+		Prefixes: []string{"get"},
+		Suffixes: []string{"app"},
+		MaxPrice: 50,
+	}
+
+	suggestions := collectSuggestions(client.SuggestAvailable(context.Background(), "example", []string{"com", ".dev"}, opts))
+
+	require.Len(t, suggestions, 3)
+
+	assert.Equal(t, "example.com", suggestions[0].Domain)
+	assert.InDelta(t, 9.68, suggestions[0].Price, 0.001)
+	require.NoError(t, suggestions[0].Err)
+
+	assert.Equal(t, "exampleapp.com", suggestions[1].Domain)
+	assert.InDelta(t, 11.00, suggestions[1].Price, 0.001)
+	require.NoError(t, suggestions[1].Err)
+
+	assert.Equal(t, "exampleapp.dev", suggestions[2].Domain)
+	require.ErrorContains(t, suggestions[2].Err, `invalid price "n/a"`)
+}
+
+func TestClient_SuggestAvailable_premium(t *testing.T) {
+	client, mux := setupMux(t)
+	WithClock(newFakeClock())(client)
+
+	mux.HandleFunc("/domain/checkDomain/", availabilityHandler(map[string]Availability{
+		"example.dev": {Available: true, Price: "1200.00", Premium: true},
+	}))
+
+	suggestions := collectSuggestions(client.SuggestAvailable(context.Background(), "example", []string{"dev"}, SuggestOptions{AllowPremium: true}))
+
+	require.Len(t, suggestions, 1)
+	assert.Equal(t, "example.dev", suggestions[0].Domain)
+	assert.True(t, bool(suggestions[0].Availability.Premium))
+}
+
+func TestClient_SuggestAvailable_rateLimited(t *testing.T) {
+	client, mux := setupMux(t)
+
+	clock := &recordingClock{fakeClock: newFakeClock()}
+	WithClock(clock)(client)
+
+	var calls int
+
+	mux.HandleFunc("/domain/checkDomain/", func(rw http.ResponseWriter, req *http.Request) {
+		calls++
+
+		switch calls {
+		case 1, 3:
+			rw.WriteHeader(http.StatusTooManyRequests)
+		case 2, 4, 5:
+			rw.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			availabilityHandler(map[string]Availability{"example.com": {Available: true, Price: "9.68"}})(rw, req)
+		}
+	})
+
+	opts := SuggestOptions{Interval: time.Second, MaxInterval: 5 * time.Second}
+
+	suggestions := collectSuggestions(client.SuggestAvailable(context.Background(), "example", []string{"com", "net"}, opts))
+
+	require.Len(t, suggestions, 1)
+	assert.Equal(t, "example.com", suggestions[0].Domain)
+
+	expected := []time.Duration{0, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second, 5 * time.Second, time.Second}
+	assert.Equal(t, expected, clock.Waits())
+}
+
+func TestClient_SuggestAvailable_maxRetries(t *testing.T) {
+	client, mux := setupMux(t)
+
+	clock := &recordingClock{fakeClock: newFakeClock()}
+	WithClock(clock)(client)
+
+	var calls int
+
+	mux.HandleFunc("/domain/checkDomain/", func(rw http.ResponseWriter, _ *http.Request) {
+		calls++
+
+		rw.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	opts := SuggestOptions{Interval: time.Second, MaxInterval: 5 * time.Second, MaxRetries: 2}
+
+	suggestions := collectSuggestions(client.SuggestAvailable(context.Background(), "example", []string{"com", "net"}, opts))
+
+	require.Len(t, suggestions, 2)
+
+	for _, suggestion := range suggestions {
+		serverErr := &ServerError{}
+		require.ErrorAs(t, suggestion.Err, &serverErr)
+		assert.Equal(t, http.StatusTooManyRequests, serverErr.StatusCode)
+	}
+
+	assert.Equal(t, "example.com", suggestions[0].Domain)
+	assert.Equal(t, "example.net", suggestions[1].Domain)
+
+	assert.Equal(t, 6, calls)
+
+	expected := []time.Duration{0, 2 * time.Second, 4 * time.Second, time.Second, 2 * time.Second, 4 * time.Second}
+	assert.Equal(t, expected, clock.Waits())
+}
+
+func TestClient_SuggestAvailable_error(t *testing.T) {
+	client := setup(t, "/domain/checkDomain/example.com", "error")
+	WithClock(newFakeClock())(client)
+
+	suggestions := collectSuggestions(client.SuggestAvailable(context.Background(), "example", []string{"com"}, SuggestOptions{}))
+
+	require.Len(t, suggestions, 1)
+	assert.Equal(t, "example.com", suggestions[0].Domain)
+	require.Error(t, suggestions[0].Err)
+}
+
+func TestClient_SuggestAvailable_canceled(t *testing.T) {
+	client, mux := setupMux(t)
+	WithClock(newFakeClock())(client)
+
+	mux.HandleFunc("/domain/checkDomain/", availabilityHandler(map[string]Availability{
+		"example.com": {Available: true, Price: "9.68"},
+		"example.net": {Available: true, Price: "9.68"},
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	suggestions := client.SuggestAvailable(ctx, "example", []string{"com", "net"}, SuggestOptions{})
+
+	first := <-suggestions
+	assert.Equal(t, "example.com", first.Domain)
+
+	cancel()
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for range suggestions {
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the channel is not closed after the cancellation")
+	}
+}
+
+func Test_suggestCandidates(t *testing.T) {
+	opts := SuggestOptions{
+		Prefixes: []string{"get", "Get"},
+		Suffixes: []string{"app"},
+	}
+
+	candidates := suggestCandidates("Example", []string{"com", ".com", "dev"}, opts)
+
+	expected := []string{
+		"example.com",
+		"example.dev",
+		"getexample.com",
+		"getexample.dev",
+		"exampleapp.com",
+		"exampleapp.dev",
+	}
+
+	assert.Equal(t, expected, candidates)
+}
@@ -0,0 +1,134 @@
+package porkbun
+
+import "strings"
+
+// DecodeTXT decodes the content of a TXT record returned by the API.
+// A content made of quoted character-strings (ex: `"v=spf1 " "-all"`) is unquoted, unescaped (\", \\, \DDD),
+// and the strings are concatenated.
+// Any other content is returned as is.
+func DecodeTXT(content string) string {
+	value, ok := unquoteTXT(content)
+	if !ok {
+		return content
+	}
+
+	return value
+}
+
+// EncodeTXT encodes the value of a TXT record for the API, DecodeTXT(EncodeTXT(value)) == value.
+// The value is sent as is, unless it would be read back as quoted character-strings:
+// in that case, it's quoted and escaped.
+func EncodeTXT(value string) string {
+	if _, ok := unquoteTXT(value); !ok {
+		return value
+	}
+
+	var b strings.Builder
+
+	b.WriteByte('"')
+
+	for i := 0; i < len(value); i++ {
+		if value[i] == '"' || value[i] == '\\' {
+			b.WriteByte('\\')
+		}
+
+		b.WriteByte(value[i])
+	}
+
+	b.WriteByte('"')
+
+	return b.String()
+}
+
+// unquoteTXT unquotes a content made of quoted character-strings separated by spaces.
+func unquoteTXT(content string) (string, bool) {
+	content = strings.TrimSpace(content)
+	if !strings.HasPrefix(content, `"`) {
+		return "", false
+	}
+
+	var b strings.Builder
+
+	for content != "" {
+		if content[0] != '"' {
+			return "", false
+		}
+
+		rest, ok := unquoteCharacterString(&b, content[1:])
+		if !ok {
+			return "", false
+		}
+
+		content = strings.TrimLeft(rest, " \t")
+	}
+
+	return b.String(), true
+}
+
+// unquoteCharacterString writes the unescaped content of a quoted character-string (without the opening quote),
+// and returns the content after the closing quote.
+func unquoteCharacterString(b *strings.Builder, s string) (string, bool) {
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			rest := s[i+1:]
+			if rest != "" && rest[0] != ' ' && rest[0] != '\t' {
+				return "", false
+			}
+
+			return rest, true
+
+		case '\\':
+			i++
+			if i >= len(s) {
+				return "", false
+			}
+
+			if isDigit(s[i]) {
+				if i+2 >= len(s) || !isDigit(s[i+1]) || !isDigit(s[i+2]) {
+					return "", false
+				}
+
+				code := int(s[i]-'0')*100 + int(s[i+1]-'0')*10 + int(s[i+2]-'0')
+				if code > 255 {
+					return "", false
+				}
+
+				b.WriteByte(byte(code))
+
+				i += 2
+
+				continue
+			}
+
+			b.WriteByte(s[i])
+
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+
+	return "", false
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// encodeTXTRecord encodes the content of a TXT record for the API.
+func encodeTXTRecord(record Record) Record {
+	if strings.EqualFold(record.Type, "TXT") {
+		record.Content = EncodeTXT(record.Content)
+	}
+
+	return record
+}
+
+// decodeTXTRecords decodes the content of the TXT records returned by the API.
+func decodeTXTRecords(records []Record) {
+	for i, record := range records {
+		if strings.EqualFold(record.Type, "TXT") {
+			records[i].Content = DecodeTXT(record.Content)
+		}
+	}
+}
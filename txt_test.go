@@ -0,0 +1,124 @@
+package porkbun
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeTXT(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		content  string
+		expected string
+	}{
+		{desc: "empty", content: "", expected: ""},
+		{desc: "raw", content: "v=spf1 mx -all", expected: "v=spf1 mx -all"},
+		{desc: "raw with semicolons", content: "v=DMARC1; p=none", expected: "v=DMARC1; p=none"},
+		{desc: "raw with inner quotes", content: `say "hello"`, expected: `say "hello"`},
+		{desc: "raw starting with a quote", content: `"hello`, expected: `"hello`},
+		{desc: "raw with text after the quotes", content: `"hello"world`, expected: `"hello"world`},
+		{desc: "quoted", content: `"v=spf1 mx -all"`, expected: "v=spf1 mx -all"},
+		{desc: "quoted with spaces around", content: ` "v=spf1 mx -all" `, expected: "v=spf1 mx -all"},
+		{desc: "quoted empty", content: `""`, expected: ""},
+		{desc: "quoted with semicolons", content: `"v=DMARC1; p=none;"`, expected: "v=DMARC1; p=none;"},
+		{desc: "multiple strings", content: `"v=spf1 " "include:example.com" " -all"`, expected: "v=spf1 include:example.com -all"},
+		{desc: "escaped quote", content: `"say \"hello\""`, expected: `say "hello"`},
+		{desc: "escaped backslash", content: `"a\\b"`, expected: `a\b`},
+		{desc: "escaped character", content: `"a\;b"`, expected: `a;b`},
+		{desc: "decimal escape", content: `"caf\195\169"`, expected: "café"},
+		{desc: "unicode", content: `"café ☕"`, expected: "café ☕"},
+		{desc: "invalid decimal escape", content: `"a\25"`, expected: `"a\25"`},
+		{desc: "out of range decimal escape", content: `"a\256"`, expected: `"a\256"`},
+		{desc: "trailing backslash", content: `"a\"`, expected: `"a\"`},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.expected, DecodeTXT(test.content))
+		})
+	}
+}
+
+func TestEncodeTXT(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		value    string
+		expected string
+	}{
+		{desc: "empty", value: "", expected: ""},
+		{desc: "plain", value: "v=spf1 mx -all", expected: "v=spf1 mx -all"},
+		{desc: "semicolons", value: "v=DMARC1; p=none", expected: "v=DMARC1; p=none"},
+		{desc: "inner quotes", value: `say "hello"`, expected: `say "hello"`},
+		{desc: "unicode", value: "café ☕", expected: "café ☕"},
+		{desc: "backslash", value: `a\b`, expected: `a\b`},
+		{desc: "looks quoted", value: `"hello"`, expected: `"\"hello\""`},
+		{desc: "looks like multiple strings", value: `"a" "b\c"`, expected: `"\"a\" \"b\\c\""`},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			encoded := EncodeTXT(test.value)
+
+			assert.Equal(t, test.expected, encoded)
+			assert.Equal(t, test.value, DecodeTXT(encoded))
+		})
+	}
+}
+
+func TestEncodeTXT_roundTrip(t *testing.T) {
+	values := []string{
+		"",
+		`"`,
+		`""`,
+		`"\"`,
+		`\"`,
+		`" "`,
+		`"a" b`,
+		"\"\xff\"",
+		"line\nbreak",
+		`"v=spf1 " "-all"`,
+		`;;"";;`,
+	}
+
+	for _, value := range values {
+		assert.Equal(t, value, DecodeTXT(EncodeTXT(value)), "value: %q", value)
+	}
+}
+
+func TestClient_TXT_roundTrip(t *testing.T) {
+	client, mux := setupMux(t)
+
+	var edited Record
+
+	mux.HandleFunc("/dns/retrieve/example.com", func(rw http.ResponseWriter, _ *http.Request) {
+		_, _ = rw.Write([]byte(`{"status":"SUCCESS","records":[{"id":"1","name":"example.com","type":"TXT","content":"\"v=spf1 \" \"-all\""}]}`))
+	})
+
+	mux.HandleFunc("/dns/edit/example.com/1", func(rw http.ResponseWriter, req *http.Request) {
+		err := json.NewDecoder(req.Body).Decode(&edited)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		fixtureHandler("edit")(rw, req)
+	})
+
+	ctx := context.Background()
+
+	records, err := client.RetrieveRecords(ctx, "example.com")
+	require.NoError(t, err)
+
+	require.Len(t, records, 1)
+	assert.Equal(t, "v=spf1 -all", records[0].Content)
+
+	err = client.EditRecord(ctx, "example.com", records[0].ID, Record{Type: "TXT", Content: `"quoted"`})
+	require.NoError(t, err)
+
+	assert.Equal(t, `"\"quoted\""`, edited.Content)
+}
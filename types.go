@@ -3,10 +3,18 @@ package porkbun
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
 )
 
 type apiRequest interface{}
 
+type apiEndpoint struct {
+	name string
+	url  *url.URL
+}
+
 type authRequest struct {
 	APIKey       string `json:"apikey"`
 	SecretAPIKey string `json:"secretapikey"`
@@ -54,15 +62,109 @@ func (a ServerError) Error() string {
 	return fmt.Sprintf("status: %d message: %s", a.StatusCode, a.Message)
 }
 
+// RecordID a DNS record ID.
+type RecordID int64
+
+func (id RecordID) String() string {
+	return strconv.FormatInt(int64(id), 10)
+}
+
+// MarshalJSON encodes the ID as a string, as the API does.
+func (id RecordID) MarshalJSON() ([]byte, error) {
+	return json.Marshal(id.String())
+}
+
+// UnmarshalJSON decodes the ID from a JSON string or a JSON number.
+func (id *RecordID) UnmarshalJSON(data []byte) error {
+	raw := string(data)
+	if raw == "null" {
+		return nil
+	}
+
+	raw = strings.Trim(raw, `"`)
+	if raw == "" {
+		*id = 0
+		return nil
+	}
+
+	value, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid record ID %s: %w", data, err)
+	}
+
+	*id = RecordID(value)
+
+	return nil
+}
+
 // Record a DNS record.
 type Record struct {
-	ID      string `json:"id,omitempty"`
-	Name    string `json:"name,omitempty"`
-	Type    string `json:"type,omitempty"`
-	Content string `json:"content,omitempty"`
-	TTL     string `json:"ttl,omitempty"`
-	Prio    string `json:"prio,omitempty"`
-	Notes   string `json:"notes,omitempty"`
+	ID      RecordID `json:"id,omitempty"`
+	Name    string   `json:"name,omitempty"`
+	Type    string   `json:"type,omitempty"`
+	Content string   `json:"content,omitempty"`
+	TTL     string   `json:"ttl,omitempty"`
+	Prio    string   `json:"prio,omitempty"`
+	Notes   string   `json:"notes,omitempty"`
+}
+
+// RecordPatch the fields to update on a DNS record.
+// A nil field keeps the current value, a pointer to an empty string clears it (ex: the notes).
+type RecordPatch struct {
+	Name    *string
+	Type    *string
+	Content *string
+	TTL     *string
+	Prio    *string
+	Notes   *string
+}
+
+func (p RecordPatch) apply(record *Record) {
+	for _, f := range []struct {
+		src *string
+		dst *string
+	}{
+		{src: p.Name, dst: &record.Name},
+		{src: p.Type, dst: &record.Type},
+		{src: p.Content, dst: &record.Content},
+		{src: p.TTL, dst: &record.TTL},
+		{src: p.Prio, dst: &record.Prio},
+		{src: p.Notes, dst: &record.Notes},
+	} {
+		if f.src != nil {
+			*f.dst = *f.src
+		}
+	}
+}
+
+// patchRequest the edit request of a patch:
+// the optional fields provided by the patch are sent even when empty, the API would keep their values otherwise.
+type patchRequest struct {
+	Name    *string `json:"name,omitempty"`
+	Type    string  `json:"type,omitempty"`
+	Content string  `json:"content,omitempty"`
+	TTL     *string `json:"ttl,omitempty"`
+	Prio    *string `json:"prio,omitempty"`
+	Notes   *string `json:"notes,omitempty"`
+}
+
+func newPatchRequest(record Record, patch RecordPatch) patchRequest {
+	field := func(value string, provided *string) *string {
+		if value == "" && provided == nil {
+			return nil
+		}
+
+		return &value
+	}
+
+	return patchRequest{
+		Name:    field(record.Name, patch.Name),
+		Type:    record.Type,
+		Content: record.Content,
+		TTL:     field(record.TTL, patch.TTL),
+		Prio:    field(record.Prio, patch.Prio),
+		Notes:   field(record.Notes, patch.Notes),
+	}
 }
 
 type pingResponse struct {
@@ -72,7 +174,7 @@ type pingResponse struct {
 
 type createResponse struct {
 	Status
-	ID int `json:"id"`
+	ID RecordID `json:"id"`
 }
 
 type retrieveResponse struct {
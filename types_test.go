@@ -0,0 +1,48 @@
+package porkbun
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordID_UnmarshalJSON(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		data     string
+		expected RecordID
+	}{
+		{desc: "number", data: `106926659`, expected: 106926659},
+		{desc: "string", data: `"106926659"`, expected: 106926659},
+		{desc: "beyond int32", data: `"9223372036854775807"`, expected: 9223372036854775807},
+		{desc: "empty string", data: `""`, expected: 0},
+		{desc: "null", data: `null`, expected: 0},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			var id RecordID
+
+			err := json.Unmarshal([]byte(test.data), &id)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, id)
+		})
+	}
+}
+
+func TestRecordID_UnmarshalJSON_error(t *testing.T) {
+	var id RecordID
+
+	err := json.Unmarshal([]byte(`"abc"`), &id)
+	require.Error(t, err)
+}
+
+func TestRecordID_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(Record{ID: 106926659})
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"id":"106926659"}`, string(data))
+}
@@ -0,0 +1,87 @@
+package porkbun
+
+import (
+	"sync"
+	"time"
+)
+
+const defaultUsageWindow = time.Hour
+
+// Usage the API calls made by the client during the usage window.
+type Usage struct {
+	// Window the duration of the sliding window.
+	Window time.Duration
+	// Total the number of API calls.
+	Total int
+	// Endpoints the number of API calls by endpoint (ex: "dns/create").
+	Endpoints map[string]int
+}
+
+// UsageHandler handles the usage when the number of API calls reaches the threshold (see WithUsageThreshold).
+type UsageHandler func(usage Usage)
+
+// Usage returns the API calls made during the usage window (see WithUsageWindow).
+func (c *Client) Usage() Usage {
+	return c.usage.snapshot(c.getClock().Now())
+}
+
+type usageCall struct {
+	endpoint string
+	at       time.Time
+}
+
+type usageTracker struct {
+	window    time.Duration
+	threshold int
+	handler   UsageHandler
+
+	mu    sync.Mutex
+	calls []usageCall
+}
+
+func (u *usageTracker) record(endpoint string, now time.Time) {
+	u.mu.Lock()
+
+	u.prune(now)
+
+	u.calls = append(u.calls, usageCall{endpoint: endpoint, at: now})
+
+	reached := u.handler != nil && u.threshold > 0 && len(u.calls) == u.threshold
+
+	u.mu.Unlock()
+
+	if reached {
+		u.handler(u.snapshot(now))
+	}
+}
+
+func (u *usageTracker) snapshot(now time.Time) Usage {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.prune(now)
+
+	usage := Usage{
+		Window:    u.window,
+		Total:     len(u.calls),
+		Endpoints: map[string]int{},
+	}
+
+	for _, call := range u.calls {
+		usage.Endpoints[call.endpoint]++
+	}
+
+	return usage
+}
+
+// prune removes the calls outside the window, the calls are sorted by time.
+func (u *usageTracker) prune(now time.Time) {
+	limit := now.Add(-u.window)
+
+	i := 0
+	for i < len(u.calls) && !u.calls[i].at.After(limit) {
+		i++
+	}
+
+	u.calls = u.calls[i:]
+}
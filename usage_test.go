@@ -0,0 +1,72 @@
+package porkbun
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Usage(t *testing.T) {
+	client, mux := setupMux(t)
+
+	mux.HandleFunc("/ping", fixtureHandler("ping"))
+	mux.HandleFunc("/dns/retrieve/example.com", fixtureHandler("retrieve"))
+
+	var notified []Usage
+
+	WithUsageThreshold(3, func(usage Usage) {
+		notified = append(notified, usage)
+	})(client)
+
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		_, err := client.Ping(ctx)
+		require.NoError(t, err)
+	}
+
+	assert.Empty(t, notified)
+
+	_, err := client.RetrieveRecords(ctx, "example.com")
+	require.NoError(t, err)
+
+	expected := Usage{
+		Window: time.Hour,
+		Total:  3,
+		Endpoints: map[string]int{
+			"ping":         2,
+			"dns/retrieve": 1,
+		},
+	}
+
+	assert.Equal(t, expected, client.Usage())
+	assert.Equal(t, []Usage{expected}, notified)
+
+	_, err = client.Ping(ctx)
+	require.NoError(t, err)
+
+	assert.Len(t, notified, 1)
+}
+
+func TestUsageTracker_window(t *testing.T) {
+	tracker := usageTracker{window: time.Minute}
+
+	now := time.Now()
+
+	tracker.calls = []usageCall{
+		{endpoint: "ping", at: now.Add(-2 * time.Minute)},
+		{endpoint: "ping", at: now.Add(-30 * time.Second)},
+		{endpoint: "dns/create", at: now.Add(-10 * time.Second)},
+	}
+
+	expected := Usage{
+		Window:    time.Minute,
+		Total:     2,
+		Endpoints: map[string]int{"ping": 1, "dns/create": 1},
+	}
+
+	assert.Equal(t, expected, tracker.snapshot(now))
+}
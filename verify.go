@@ -0,0 +1,68 @@
+package porkbun
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DeletionNotVerifiedError is returned when a deleted record still exists after all the verification attempts.
+type DeletionNotVerifiedError struct {
+	Domain   string
+	ID       RecordID
+	Attempts int
+}
+
+func (e DeletionNotVerifiedError) Error() string {
+	return fmt.Sprintf("porkbun: record %s of %s still exists after %d deletion attempts", e.ID, e.Domain, e.Attempts)
+}
+
+type deleteVerification struct {
+	attempts int
+	delay    time.Duration
+}
+
+// verifyDeletion checks that the record is gone after the delay, the deletion is retried while the record persists.
+func (c *Client) verifyDeletion(ctx context.Context, domain string, id RecordID) error {
+	for attempt := 1; ; attempt++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-c.getClock().After(c.deleteVerification.delay):
+		}
+
+		gone, err := c.recordGone(ctx, domain, id)
+		if err != nil {
+			return fmt.Errorf("failed to verify the deletion of the record %s: %w", id, err)
+		}
+
+		if gone {
+			return nil
+		}
+
+		if attempt >= c.deleteVerification.attempts {
+			return &DeletionNotVerifiedError{Domain: domain, ID: id, Attempts: attempt}
+		}
+
+		err = c.deleteRecord(ctx, domain, id)
+		if err != nil {
+			// the API rejects the deletion of a record that is finally gone.
+			if gone, errG := c.recordGone(ctx, domain, id); errG == nil && gone {
+				return nil
+			}
+
+			return err
+		}
+	}
+}
+
+// recordGone returns true when the record doesn't exist.
+func (c *Client) recordGone(ctx context.Context, domain string, id RecordID) (bool, error) {
+	_, err := c.retrieveRecord(ctx, domain, id)
+	if errors.Is(err, ErrRecordNotFound) {
+		return true, nil
+	}
+
+	return false, err
+}
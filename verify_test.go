@@ -0,0 +1,106 @@
+package porkbun
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupDeleteVerification(t *testing.T, persists int) (*Client, *int) {
+	t.Helper()
+
+	client, mux := setupMux(t)
+
+	var deletes int
+
+	mux.HandleFunc("/dns/delete/example.com/666", func(rw http.ResponseWriter, req *http.Request) {
+		deletes++
+
+		fixtureHandler("delete")(rw, req)
+	})
+
+	mux.HandleFunc("/dns/retrieve/example.com/666", func(rw http.ResponseWriter, req *http.Request) {
+		if deletes <= persists {
+			fixtureHandler("retrieve-id")(rw, req)
+			return
+		}
+
+		fixtureHandler("retrieve-empty")(rw, req)
+	})
+
+	return client, &deletes
+}
+
+func TestWithDeleteVerification(t *testing.T) {
+	client, deletes := setupDeleteVerification(t, 1)
+	WithDeleteVerification(3, 0)(client)
+
+	err := client.DeleteRecord(context.Background(), "example.com", 666)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, *deletes)
+}
+
+func TestWithDeleteVerification_persists(t *testing.T) {
+	client, deletes := setupDeleteVerification(t, 10)
+	WithDeleteVerification(3, 0)(client)
+
+	err := client.DeleteRecord(context.Background(), "example.com", 666)
+
+	notVerifiedErr := &DeletionNotVerifiedError{}
+	require.ErrorAs(t, err, &notVerifiedErr)
+	assert.Equal(t, 3, notVerifiedErr.Attempts)
+
+	assert.Equal(t, 3, *deletes)
+}
+
+func TestWithDeleteVerification_retryError(t *testing.T) {
+	client, mux := setupMux(t)
+	WithDeleteVerification(3, 0)(client)
+
+	var deletes int
+
+	// the record is gone after the first deletion, but the API is eventually consistent:
+	// the first check still finds the record, and the second deletion fails on the unknown ID.
+	mux.HandleFunc("/dns/delete/example.com/666", func(rw http.ResponseWriter, req *http.Request) {
+		deletes++
+
+		if deletes > 1 {
+			fixtureHandler("error")(rw, req)
+			return
+		}
+
+		fixtureHandler("delete")(rw, req)
+	})
+
+	var retrieves int
+
+	mux.HandleFunc("/dns/retrieve/example.com/666", func(rw http.ResponseWriter, req *http.Request) {
+		retrieves++
+
+		if retrieves == 1 {
+			fixtureHandler("retrieve-id")(rw, req)
+			return
+		}
+
+		fixtureHandler("retrieve-empty")(rw, req)
+	})
+
+	err := client.DeleteRecord(context.Background(), "example.com", 666)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, deletes)
+	assert.Equal(t, 2, retrieves)
+}
+
+func TestWithDeleteVerification_disabled(t *testing.T) {
+	client, deletes := setupDeleteVerification(t, 10)
+
+	err := client.DeleteRecord(context.Background(), "example.com", 666)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, *deletes)
+}